@@ -0,0 +1,70 @@
+// Package animation provides tick based tweens so animations follow the game's update loop instead of the wall clock.
+package animation
+
+import "math"
+
+// Easing maps linear progress in [0, 1] to eased progress
+type Easing func(t float64) float64
+
+func Linear(t float64) float64 {
+	return t
+}
+
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+func EaseOutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// EaseOutBack overshoots the end slightly before settling on it
+func EaseOutBack(t float64) float64 {
+	const overshoot = 1.70158
+	return 1 + (overshoot+1)*math.Pow(t-1, 3) + overshoot*math.Pow(t-1, 2)
+}
+
+// Tween tracks the progress of an animation that lasts a fixed number of ticks
+type Tween struct {
+	duration, elapsed int
+	easing            Easing
+}
+
+func NewTween(durationTicks int, easing Easing) *Tween {
+	if easing == nil {
+		easing = Linear
+	}
+	return &Tween{
+		duration: durationTicks,
+		easing:   easing,
+	}
+}
+
+// Update advances the tween by one tick
+func (this *Tween) Update() {
+	if this.elapsed < this.duration {
+		this.elapsed++
+	}
+}
+
+func (this *Tween) Done() bool {
+	return this.elapsed >= this.duration
+}
+
+// Progress returns how far along the tween is from 0 to 1 without easing
+func (this *Tween) Progress() float64 {
+	if this.duration <= 0 {
+		return 1
+	}
+	return float64(this.elapsed) / float64(this.duration)
+}
+
+// Value returns the eased progress of the tween
+func (this *Tween) Value() float64 {
+	return this.easing(this.Progress())
+}
+
+// Lerp interpolates between from and to, returning from when t is 0 and to when t is 1
+func Lerp(from, to, t float64) float64 {
+	return from + (to-from)*t
+}
@@ -0,0 +1,82 @@
+package animation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTween_Value(t *testing.T) {
+	type args struct {
+		duration int
+		ticks    int
+		easing   Easing
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{name: "not started", args: args{duration: 10, ticks: 0, easing: Linear}, want: 0},
+		{name: "linear halfway", args: args{duration: 10, ticks: 5, easing: Linear}, want: 0.5},
+		{name: "done", args: args{duration: 10, ticks: 10, easing: Linear}, want: 1},
+		{name: "stops at the end", args: args{duration: 10, ticks: 25, easing: Linear}, want: 1},
+		{name: "no duration", args: args{duration: 0, ticks: 0, easing: Linear}, want: 1},
+		{name: "nil easing is linear", args: args{duration: 4, ticks: 1, easing: nil}, want: 0.25},
+		{name: "ease in cubic halfway", args: args{duration: 10, ticks: 5, easing: EaseInCubic}, want: 0.125},
+		{name: "ease out cubic halfway", args: args{duration: 10, ticks: 5, easing: EaseOutCubic}, want: 0.875},
+		{name: "ease out back ends at one", args: args{duration: 10, ticks: 10, easing: EaseOutBack}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tween := NewTween(tt.args.duration, tt.args.easing)
+			for range tt.args.ticks {
+				tween.Update()
+			}
+			if got := tween.Value(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTween_Done(t *testing.T) {
+	tween := NewTween(3, Linear)
+	for i := range 3 {
+		if tween.Done() {
+			t.Fatalf("Done() = true after %d ticks, want false", i)
+		}
+		tween.Update()
+	}
+	if !tween.Done() {
+		t.Errorf("Done() = false after 3 ticks, want true")
+	}
+}
+
+func TestEaseOutBack_overshoots(t *testing.T) {
+	if got := EaseOutBack(0.8); got <= 1 {
+		t.Errorf("EaseOutBack(0.8) = %v, want > 1", got)
+	}
+}
+
+func TestLerp(t *testing.T) {
+	type args struct {
+		from, to, t float64
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{name: "start", args: args{from: 2, to: 4, t: 0}, want: 2},
+		{name: "middle", args: args{from: 2, to: 4, t: 0.5}, want: 3},
+		{name: "end", args: args{from: 2, to: 4, t: 1}, want: 4},
+		{name: "shrinking", args: args{from: 1, to: 0, t: 0.25}, want: 0.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lerp(tt.args.from, tt.args.to, tt.args.t); got != tt.want {
+				t.Errorf("Lerp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
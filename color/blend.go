@@ -0,0 +1,44 @@
+package color
+
+import (
+	"image/color"
+	"math"
+)
+
+// Lerp returns the color t of the way from from to to, t is clamped to 0 to 1
+func Lerp(from, to color.RGBA, t float64) color.RGBA {
+	t = math.Min(math.Max(t, 0), 1)
+	channel := func(from, to uint8) uint8 {
+		return uint8(math.Round(float64(from) + (float64(to)-float64(from))*t))
+	}
+	return color.RGBA{
+		R: channel(from.R, to.R),
+		G: channel(from.G, to.G),
+		B: channel(from.B, to.B),
+		A: channel(from.A, to.A),
+	}
+}
+
+// Blend returns top drawn over bottom, both are premultiplied like every color.RGBA
+func Blend(bottom, top color.RGBA) color.RGBA {
+	over := func(bottomChannel, topChannel uint8) uint8 {
+		return topChannel + uint8((uint16(bottomChannel)*uint16(255-top.A)+127)/255)
+	}
+	return color.RGBA{
+		R: over(bottom.R, top.R),
+		G: over(bottom.G, top.G),
+		B: over(bottom.B, top.B),
+		A: over(bottom.A, top.A),
+	}
+}
+
+// Lighten returns the opaque color with its OKLCH lightness raised by amount, from 0 to 1
+func Lighten(clr color.RGBA, amount float64) color.RGBA {
+	l, c, h := RGBToOKLCH(clr)
+	return OKLCHToRGB(l+amount, c, h)
+}
+
+// Darken returns the opaque color with its OKLCH lightness lowered by amount, from 0 to 1
+func Darken(clr color.RGBA, amount float64) color.RGBA {
+	return Lighten(clr, -amount)
+}
@@ -1,23 +1,33 @@
 package color
 
 import (
+	"fmt"
 	"image/color"
 	"strconv"
+	"strings"
 )
 
 var Green = color.RGBA{R: 0, G: 255, B: 0, A: 255}
 
 type Theme struct {
+	Name                    string // shown when picking a theme and saved to remember the pick
 	BackgroundColor         color.RGBA
 	HexBorderColor          color.RGBA
 	ConnectionColor         color.RGBA
 	PendingHexBorderColor   color.RGBA
 	PendingConnectionColors []color.RGBA
 	CompletedLoopColor      color.RGBA
+	Patterns                bool       // tell pending paths apart by dashes and dots and outline completed loops, not just by color
+	ShimmerColor            color.RGBA // swept across the hex borders by a Shimmer animation, transparent for none
+	ShimmerPosition         float64    // how far through its sweep the shimmer is, past 1 when it is resting
+	Animation               Animation  // changes the colors each tick, nil for themes that don't change
+
+	base *Theme // the colors before the animation changed them
 }
 
 func NewBeeTheme() *Theme {
 	return &Theme{
+		Name:                  "Bee",
 		BackgroundColor:       color.RGBA{R: 251, G: 217, B: 100, A: 255}, // Bee Yellow
 		HexBorderColor:        color.RGBA{R: 254, G: 237, B: 161, A: 255}, // Beige
 		ConnectionColor:       color.RGBA{R: 133, G: 77, B: 13, A: 255},   // Brown
@@ -33,6 +43,7 @@ func NewBeeTheme() *Theme {
 
 func NewBlueTheme() *Theme {
 	return &Theme{
+		Name:                  "Blue",
 		BackgroundColor:       HexToRGB("#53687E"), // Payne's Gray
 		HexBorderColor:        HexToRGB("#3A4454"), // Charcoal
 		ConnectionColor:       HexToRGB("#C2B2B4"), // French Gray
@@ -45,24 +56,156 @@ func NewBlueTheme() *Theme {
 		CompletedLoopColor: Green,
 	}
 }
+
+// NewBeeShimmerTheme returns the Bee theme with a cream shimmer sweeping across the hex borders now and then
+func NewBeeShimmerTheme() *Theme {
+	theme := NewBeeTheme()
+	theme.Name = "Bee Shimmer"
+	theme.ShimmerColor = color.RGBA{R: 255, G: 250, B: 225, A: 255} // Cream
+	theme.Animation = &Shimmer{SweepTicks: 2 * 60, PeriodTicks: 8 * 60}
+	return theme
+}
+
+// NewBlueDriftTheme returns the Blue theme with the hue of the background and hex borders slowly drifting
+func NewBlueDriftTheme() *Theme {
+	theme := NewBlueTheme()
+	theme.Name = "Blue Drift"
+	theme.Animation = &HueShift{Degrees: 20, PeriodTicks: 60 * 60}
+	return theme
+}
+
+// NewDayNightTheme returns a theme that is the Bee theme during the day and the Blue theme at night
+func NewDayNightTheme() *Theme {
+	theme := &Theme{
+		Name: "Day & Night",
+		Animation: &DayNight{
+			Day:        NewBeeTheme(),
+			Night:      NewBlueTheme(),
+			DayStart:   7,
+			NightStart: 19,
+		},
+	}
+	theme.Update(0)
+	return theme
+}
+
+// the colorblind themes use the Okabe-Ito palette, which stays distinguishable with each kind of color blindness,
+// and keep the background, hex borders and connections at least MinContrastRatio apart
+
+// NewDeuteranopiaTheme returns a dark theme that avoids telling red from green
+func NewDeuteranopiaTheme() *Theme {
+	return &Theme{
+		Name:                  "Deuteranopia",
+		BackgroundColor:       HexToRGB("#101820"), // Ink
+		HexBorderColor:        HexToRGB("#5F6F80"), // Slate
+		ConnectionColor:       HexToRGB("#F5F5F5"), // White Smoke
+		PendingHexBorderColor: HexToRGB("#F0E442"), // Yellow
+		PendingConnectionColors: []color.RGBA{
+			HexToRGB("#E69F00"), // Orange
+			HexToRGB("#56B4E9"), // Sky Blue
+			HexToRGB("#CC79A7"), // Reddish Purple
+		},
+		CompletedLoopColor: HexToRGB("#009E73"), // Bluish Green
+		Patterns:           true,
+	}
+}
+
+// NewProtanopiaTheme returns a light theme that avoids telling red from green,
+// reds look darker with protanopia so the pending colors are dark enough to stand out from the background
+func NewProtanopiaTheme() *Theme {
+	return &Theme{
+		Name:                  "Protanopia",
+		BackgroundColor:       HexToRGB("#F7F4EC"), // Paper
+		HexBorderColor:        HexToRGB("#8A8375"), // Stone
+		ConnectionColor:       HexToRGB("#1F1F1F"), // Charcoal
+		PendingHexBorderColor: HexToRGB("#E69F00"), // Orange
+		PendingConnectionColors: []color.RGBA{
+			HexToRGB("#D55E00"), // Vermillion
+			HexToRGB("#0072B2"), // Blue
+			HexToRGB("#882255"), // Wine
+		},
+		CompletedLoopColor: HexToRGB("#009E73"), // Bluish Green
+		Patterns:           true,
+	}
+}
+
+// NewTritanopiaTheme returns a dark theme that avoids telling blue from green and yellow from violet
+func NewTritanopiaTheme() *Theme {
+	return &Theme{
+		Name:                  "Tritanopia",
+		BackgroundColor:       HexToRGB("#1A1A1A"), // Eerie Black
+		HexBorderColor:        HexToRGB("#6E6E6E"), // Dim Gray
+		ConnectionColor:       HexToRGB("#F2F2F2"), // Anti-flash White
+		PendingHexBorderColor: HexToRGB("#56B4E9"), // Sky Blue
+		PendingConnectionColors: []color.RGBA{
+			HexToRGB("#D55E00"), // Vermillion
+			HexToRGB("#CC79A7"), // Reddish Purple
+			HexToRGB("#009E73"), // Bluish Green
+		},
+		CompletedLoopColor: HexToRGB("#F0E442"), // Yellow
+		Patterns:           true,
+	}
+}
+
 func NewDefaultTheme() *Theme {
 	return NewBeeTheme()
 }
 
-// HexToRGB converts a 6-digit hex color code to RGB values.
-func HexToRGB(hexCode string) color.RGBA {
-	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
-	if len(hexCode) > 6 {
-		hexCode = hexCode[len(hexCode)-6:]
+// Themes returns each of the built-in themes in the order they are offered to the player
+func Themes() []*Theme {
+	return []*Theme{
+		NewBeeTheme(), NewBlueTheme(), NewBeeShimmerTheme(), NewBlueDriftTheme(), NewDayNightTheme(),
+		NewDeuteranopiaTheme(), NewProtanopiaTheme(), NewTritanopiaTheme(),
 	}
-	if len(hexCode) != 6 {
-		return white
+}
+
+// ThemeByName returns the built-in theme with the given name
+func ThemeByName(name string) (*Theme, bool) {
+	for _, theme := range Themes() {
+		if theme.Name == name {
+			return theme, true
+		}
 	}
+	return nil, false
+}
 
-	// Convert the hex string to its RGB components
-	r, _ := strconv.ParseInt(hexCode[0:2], 16, 32)
-	g, _ := strconv.ParseInt(hexCode[2:4], 16, 32)
-	b, _ := strconv.ParseInt(hexCode[4:6], 16, 32)
+// HexToRGB converts a hex color code to RGB values, returning white when the code isn't valid.
+// ParseHex reports what is wrong with the code instead.
+func HexToRGB(hexCode string) color.RGBA {
+	clr, err := ParseHex(hexCode)
+	if err != nil {
+		return color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	}
+	return clr
+}
 
-	return color.RGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 255}
+// ParseHex parses a #RGB, #RGBA, #RRGGBB or #RRGGBBAA color code, the # is optional.
+// Translucent colors are premultiplied as color.RGBA expects.
+func ParseHex(hexCode string) (color.RGBA, error) {
+	digits := strings.TrimPrefix(hexCode, "#")
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil || (len(digits) != 3 && len(digits) != 4 && len(digits) != 6 && len(digits) != 8) {
+		return color.RGBA{}, fmt.Errorf("invalid color %q, want #RGB, #RGBA, #RRGGBB or #RRGGBBAA", hexCode)
+	}
+	var channels [4]uint8
+	channels[3] = 255
+	switch len(digits) {
+	case 3, 4:
+		// each digit is repeated, #F80 is #FF8800
+		for i := range len(digits) {
+			digit := uint8(value >> (4 * (len(digits) - 1 - i)) & 0xf)
+			channels[i] = digit<<4 | digit
+		}
+	case 6, 8:
+		for i := range len(digits) / 2 {
+			channels[i] = uint8(value >> (8 * (len(digits)/2 - 1 - i)))
+		}
+	}
+	r, g, b, a := channels[0], channels[1], channels[2], channels[3]
+	return color.RGBA{
+		R: uint8(uint16(r) * uint16(a) / 255),
+		G: uint8(uint16(g) * uint16(a) / 255),
+		B: uint8(uint16(b) * uint16(a) / 255),
+		A: a,
+	}, nil
 }
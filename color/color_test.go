@@ -0,0 +1,27 @@
+package color
+
+import "testing"
+
+func TestThemeByName(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOk bool
+	}{
+		{name: "Bee", want: "Bee", wantOk: true},
+		{name: "Blue", want: "Blue", wantOk: true},
+		{name: "blue", wantOk: false},
+		{name: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ThemeByName(tt.name)
+			if ok != tt.wantOk {
+				t.Fatalf("ThemeByName() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && got.Name != tt.want {
+				t.Errorf("ThemeByName() = %q, want %q", got.Name, tt.want)
+			}
+		})
+	}
+}
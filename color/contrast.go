@@ -0,0 +1,69 @@
+package color
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+)
+
+// MinContrastRatio is the WCAG minimum contrast for graphics needed to understand the screen, 3:1
+const MinContrastRatio = 3.0
+
+// RelativeLuminance returns the WCAG relative luminance of the color, 0 for black to 1 for white
+func RelativeLuminance(clr color.RGBA) float64 {
+	linear := func(channel uint8) float64 {
+		c := float64(channel) / 255
+		if clr.A > 0 && clr.A < 255 {
+			c = math.Min(c*255/float64(clr.A), 1) // un-premultiply
+		}
+		if c <= 0.04045 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(clr.R) + 0.7152*linear(clr.G) + 0.0722*linear(clr.B)
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors, from 1 for the same color to 21 for black and white
+func ContrastRatio(a, b color.RGBA) float64 {
+	lighter, darker := RelativeLuminance(a), RelativeLuminance(b)
+	if lighter < darker {
+		lighter, darker = darker, lighter
+	}
+	return (lighter + 0.05) / (darker + 0.05)
+}
+
+// ContrastWarning is a pair of a theme's colors that are hard to tell apart
+type ContrastWarning struct {
+	Foreground, Background string // theme file field names
+	Ratio, MinRatio        float64
+}
+
+func (this ContrastWarning) String() string {
+	return fmt.Sprintf("%s on %s has a contrast ratio of %.2f:1, below %.2g:1", this.Foreground, this.Background, this.Ratio, this.MinRatio)
+}
+
+// CheckContrast returns a warning for each pair of the background, connection and hex border colors
+// with a contrast ratio below minRatio
+func (this *Theme) CheckContrast(minRatio float64) []ContrastWarning {
+	pairs := []struct {
+		foreground, background           string
+		foregroundColor, backgroundColor color.RGBA
+	}{
+		{"connectionColor", "backgroundColor", this.ConnectionColor, this.BackgroundColor},
+		{"hexBorderColor", "backgroundColor", this.HexBorderColor, this.BackgroundColor},
+		{"connectionColor", "hexBorderColor", this.ConnectionColor, this.HexBorderColor},
+	}
+	var warnings []ContrastWarning
+	for _, pair := range pairs {
+		if ratio := ContrastRatio(pair.foregroundColor, pair.backgroundColor); ratio < minRatio {
+			warnings = append(warnings, ContrastWarning{
+				Foreground: pair.foreground,
+				Background: pair.background,
+				Ratio:      ratio,
+				MinRatio:   minRatio,
+			})
+		}
+	}
+	return warnings
+}
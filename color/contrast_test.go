@@ -0,0 +1,68 @@
+package color
+
+import (
+	"image/color"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestContrastRatio(t *testing.T) {
+	black := color.RGBA{A: 255}
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	tests := []struct {
+		name string
+		a, b color.RGBA
+		want float64
+	}{
+		{name: "black on white", a: black, b: white, want: 21},
+		{name: "white on black", a: white, b: black, want: 21},
+		{name: "same color", a: HexToRGB("#53687E"), b: HexToRGB("#53687E"), want: 1},
+		{name: "gray on white", a: HexToRGB("#777777"), b: white, want: 4.48},
+		{name: "translucent is un-premultiplied", a: color.RGBA{A: 128}, b: white, want: 21},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContrastRatio(tt.a, tt.b); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ContrastRatio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTheme_CheckContrast(t *testing.T) {
+	type pair struct{ foreground, background string }
+	tests := []struct {
+		name  string
+		theme *Theme
+		want  []pair
+	}{
+		{name: "Bee", theme: NewBeeTheme(), want: []pair{{"hexBorderColor", "backgroundColor"}}},
+		{name: "Blue", theme: NewBlueTheme(), want: []pair{{"connectionColor", "backgroundColor"}, {"hexBorderColor", "backgroundColor"}}},
+		{name: "Deuteranopia", theme: NewDeuteranopiaTheme()},
+		{name: "Protanopia", theme: NewProtanopiaTheme()},
+		{name: "Tritanopia", theme: NewTritanopiaTheme()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []pair
+			for _, warning := range tt.theme.CheckContrast(MinContrastRatio) {
+				got = append(got, pair{warning.Foreground, warning.Background})
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("CheckContrast() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// the colorblind themes' pending and completed loop colors have to stand out from the background too
+func TestColorblindThemes_highlightContrast(t *testing.T) {
+	for _, theme := range []*Theme{NewDeuteranopiaTheme(), NewProtanopiaTheme(), NewTritanopiaTheme()} {
+		for _, clr := range append(slices.Clone(theme.PendingConnectionColors), theme.CompletedLoopColor) {
+			if ratio := ContrastRatio(clr, theme.BackgroundColor); ratio < MinContrastRatio {
+				t.Errorf("%s: %v on the background has a contrast ratio of %.2f", theme.Name, clr, ratio)
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package color
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestRGBToHSL(t *testing.T) {
+	tests := []struct {
+		name    string
+		clr     color.RGBA
+		h, s, l float64
+	}{
+		{name: "red", clr: color.RGBA{R: 255, A: 255}, h: 0, s: 1, l: 0.5},
+		{name: "blue", clr: color.RGBA{B: 255, A: 255}, h: 240, s: 1, l: 0.5},
+		{name: "gray", clr: color.RGBA{R: 128, G: 128, B: 128, A: 255}, h: 0, s: 0, l: 128.0 / 255},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, s, l := RGBToHSL(tt.clr)
+			if h != tt.h || s != tt.s || l != tt.l {
+				t.Errorf("RGBToHSL() = %v, %v, %v, want %v, %v, %v", h, s, l, tt.h, tt.s, tt.l)
+			}
+			if got := HSLToRGB(h, s, l); got != tt.clr {
+				t.Errorf("HSLToRGB() = %v, want %v", got, tt.clr)
+			}
+		})
+	}
+}
+
+func TestRGBToOKLCH(t *testing.T) {
+	tests := []struct {
+		name    string
+		clr     color.RGBA
+		l, c, h float64
+	}{
+		{name: "white", clr: color.RGBA{R: 255, G: 255, B: 255, A: 255}, l: 1, c: 0, h: 0},
+		{name: "black", clr: color.RGBA{A: 255}, l: 0, c: 0, h: 0},
+		{name: "red", clr: color.RGBA{R: 255, A: 255}, l: 0.628, c: 0.258, h: 29.2},
+		{name: "blue", clr: color.RGBA{B: 255, A: 255}, l: 0.452, c: 0.313, h: 264.1},
+	}
+	near := func(got, want, tolerance float64) bool {
+		return math.Abs(got-want) <= tolerance
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, c, h := RGBToOKLCH(tt.clr)
+			if !near(l, tt.l, 0.001) || !near(c, tt.c, 0.001) || !near(h, tt.h, 0.1) {
+				t.Errorf("RGBToOKLCH() = %v, %v, %v, want %v, %v, %v", l, c, h, tt.l, tt.c, tt.h)
+			}
+			if got := OKLCHToRGB(l, c, h); got != tt.clr {
+				t.Errorf("OKLCHToRGB() = %v, want %v", got, tt.clr)
+			}
+		})
+	}
+}
+
+func TestOKLCHToRGB_outOfGamut(t *testing.T) {
+	// far more chroma than any sRGB color has, the color keeps its lightness and hue
+	got := OKLCHToRGB(0.7, 1, 145)
+	l, _, h := RGBToOKLCH(got)
+	if math.Abs(l-0.7) > 0.005 || math.Abs(h-145) > 2 {
+		t.Errorf("OKLCHToRGB() = %v with lightness %v and hue %v, want 0.7 and 145", got, l, h)
+	}
+}
+
+func TestLerp(t *testing.T) {
+	black := color.RGBA{A: 255}
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	tests := []struct {
+		name string
+		t    float64
+		want color.RGBA
+	}{
+		{name: "start", t: 0, want: black},
+		{name: "middle", t: 0.5, want: color.RGBA{R: 128, G: 128, B: 128, A: 255}},
+		{name: "end", t: 1, want: white},
+		{name: "past the end", t: 1.5, want: white},
+		{name: "before the start", t: -1, want: black},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lerp(black, white, tt.t); got != tt.want {
+				t.Errorf("Lerp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlend(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	tests := []struct {
+		name        string
+		bottom, top color.RGBA
+		want        color.RGBA
+	}{
+		{name: "opaque top", bottom: red, top: color.RGBA{B: 255, A: 255}, want: color.RGBA{B: 255, A: 255}},
+		{name: "transparent top", bottom: red, top: color.RGBA{}, want: red},
+		{name: "half blue", bottom: red, top: color.RGBA{B: 128, A: 128}, want: color.RGBA{R: 127, B: 128, A: 255}},
+		{name: "over nothing", bottom: color.RGBA{}, top: color.RGBA{B: 128, A: 128}, want: color.RGBA{B: 128, A: 128}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Blend(tt.bottom, tt.top); got != tt.want {
+				t.Errorf("Blend() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLighten(t *testing.T) {
+	clr := color.RGBA{R: 0x53, G: 0x68, B: 0x7e, A: 255}
+	l, _, _ := RGBToOKLCH(clr)
+	lighter, _, _ := RGBToOKLCH(Lighten(clr, 0.1))
+	darker, _, _ := RGBToOKLCH(Darken(clr, 0.1))
+	if math.Abs(lighter-(l+0.1)) > 0.005 {
+		t.Errorf("Lighten() lightness = %v, want %v", lighter, l+0.1)
+	}
+	if math.Abs(darker-(l-0.1)) > 0.005 {
+		t.Errorf("Darken() lightness = %v, want %v", darker, l-0.1)
+	}
+	if got := Lighten(clr, 2); got != (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("Lighten() past white = %v, want white", got)
+	}
+}
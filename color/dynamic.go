@@ -0,0 +1,122 @@
+package color
+
+import (
+	"image/color"
+	"math"
+	"slices"
+	"time"
+)
+
+// shimmerWidth is the width of the shimmer's band of light as a fraction of the screen
+const shimmerWidth = 0.15
+
+// Animation changes a theme's colors as the game runs
+type Animation interface {
+	// Update sets the theme's colors for the tick, base is the theme as it was defined
+	Update(theme, base *Theme, tick int)
+}
+
+// Update animates the theme for the tick and reports whether any of its colors changed,
+// themes without an animation never change
+func (this *Theme) Update(tick int) bool {
+	if this.Animation == nil {
+		return false
+	}
+	if this.base == nil {
+		this.base = this.clone()
+	}
+	before := *this
+	this.Animation.Update(this, this.base, tick)
+	return !sameColors(&before, this)
+}
+
+// ShimmerAt returns how strongly the shimmer lights up x, a fraction of the way across the screen, from 0 to 1
+func (this *Theme) ShimmerAt(x float64) float64 {
+	if this.ShimmerColor.A == 0 {
+		return 0
+	}
+	// the band starts off the left of the screen and ends off the right
+	center := -shimmerWidth + this.ShimmerPosition*(1+2*shimmerWidth)
+	distance := math.Abs(x-center) / shimmerWidth
+	if distance >= 1 {
+		return 0
+	}
+	return (1 + math.Cos(distance*math.Pi)) / 2
+}
+
+func (this *Theme) clone() *Theme {
+	clone := *this
+	clone.PendingConnectionColors = append([]color.RGBA(nil), this.PendingConnectionColors...)
+	clone.base = nil
+	return &clone
+}
+
+// copyColors sets dst's colors to src's, leaving its name and animation
+func copyColors(dst, src *Theme) {
+	dst.BackgroundColor = src.BackgroundColor
+	dst.HexBorderColor = src.HexBorderColor
+	dst.ConnectionColor = src.ConnectionColor
+	dst.PendingHexBorderColor = src.PendingHexBorderColor
+	dst.PendingConnectionColors = src.PendingConnectionColors
+	dst.CompletedLoopColor = src.CompletedLoopColor
+	dst.Patterns = src.Patterns
+	dst.ShimmerColor = src.ShimmerColor
+	dst.ShimmerPosition = src.ShimmerPosition
+}
+
+// sameColors reports whether a and b draw the same, the shimmer is drawn over everything else so it isn't compared
+func sameColors(a, b *Theme) bool {
+	return a.BackgroundColor == b.BackgroundColor &&
+		a.HexBorderColor == b.HexBorderColor &&
+		a.ConnectionColor == b.ConnectionColor &&
+		a.PendingHexBorderColor == b.PendingHexBorderColor &&
+		slices.Equal(a.PendingConnectionColors, b.PendingConnectionColors) &&
+		a.CompletedLoopColor == b.CompletedLoopColor &&
+		a.Patterns == b.Patterns
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HueShift slowly swings the hue of the background and hex borders back and forth
+type HueShift struct {
+	Degrees     float64 // furthest the hue swings either way
+	PeriodTicks int     // ticks to swing there and back
+}
+
+func (this *HueShift) Update(theme, base *Theme, tick int) {
+	// whole degrees so the colors only change, and the board is only redrawn, every few ticks
+	degrees := math.Round(this.Degrees * math.Sin(2*math.Pi*float64(tick)/float64(this.PeriodTicks)))
+	theme.BackgroundColor = RotateHue(base.BackgroundColor, degrees)
+	theme.HexBorderColor = RotateHue(base.HexBorderColor, degrees)
+}
+
+// Shimmer sweeps a band of ShimmerColor across the hex borders, then rests until the next sweep
+type Shimmer struct {
+	SweepTicks  int // ticks for the band to cross the screen
+	PeriodTicks int // ticks from the start of one sweep to the next
+}
+
+func (this *Shimmer) Update(theme, base *Theme, tick int) {
+	theme.ShimmerPosition = float64(tick%this.PeriodTicks) / float64(this.SweepTicks)
+}
+
+// DayNight uses the day theme's colors during the day and the night theme's at night by the local time,
+// animating whichever is in use
+type DayNight struct {
+	Day, Night           *Theme
+	DayStart, NightStart int              // hours of the day
+	Now                  func() time.Time // time.Now when nil
+}
+
+func (this *DayNight) Update(theme, base *Theme, tick int) {
+	now := time.Now
+	if this.Now != nil {
+		now = this.Now
+	}
+	current := this.Night
+	if hour := now().Hour(); hour >= this.DayStart && hour < this.NightStart {
+		current = this.Day
+	}
+	current.Update(tick)
+	copyColors(theme, current)
+}
@@ -0,0 +1,104 @@
+package color
+
+import (
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestTheme_Update(t *testing.T) {
+	tests := []struct {
+		name        string
+		theme       func() *Theme
+		tick        int
+		wantChanged bool
+	}{
+		{name: "static theme", theme: NewDeuteranopiaTheme, tick: 100, wantChanged: false},
+		{name: "Bee is static", theme: NewBeeTheme, tick: 60, wantChanged: false},
+		{name: "Blue is static", theme: NewBlueTheme, tick: 15 * 60, wantChanged: false},
+		{name: "hue shift at the start", theme: NewBlueDriftTheme, tick: 0, wantChanged: false},
+		{name: "hue shift a quarter period in", theme: NewBlueDriftTheme, tick: 15 * 60, wantChanged: true},
+		{name: "shimmer only moves the shimmer", theme: NewBeeShimmerTheme, tick: 60, wantChanged: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme := tt.theme()
+			if got := theme.Update(tt.tick); got != tt.wantChanged {
+				t.Errorf("Update() = %v, want %v", got, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestHueShift_Update(t *testing.T) {
+	theme := NewBlueTheme()
+	base := NewBlueTheme()
+	shift := &HueShift{Degrees: 90, PeriodTicks: 400}
+
+	shift.Update(theme, base, 100)
+	if want := RotateHue(base.BackgroundColor, 90); theme.BackgroundColor != want {
+		t.Errorf("BackgroundColor = %v, want %v", theme.BackgroundColor, want)
+	}
+	if theme.ConnectionColor != base.ConnectionColor {
+		t.Errorf("ConnectionColor = %v, want it unchanged", theme.ConnectionColor)
+	}
+
+	// back where it started after a full period
+	shift.Update(theme, base, 400)
+	if theme.BackgroundColor != base.BackgroundColor {
+		t.Errorf("BackgroundColor = %v, want %v", theme.BackgroundColor, base.BackgroundColor)
+	}
+}
+
+func TestTheme_ShimmerAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		position float64
+		x        float64
+		want     float64
+	}{
+		{name: "center of the band", position: 0.5, x: 0.5, want: 1},
+		{name: "halfway out of the band", position: 0.5, x: 0.5 + shimmerWidth/2, want: 0.5},
+		{name: "outside the band", position: 0.5, x: 0.9, want: 0},
+		{name: "band starts off the screen", position: 0, x: 0, want: 0},
+		{name: "resting", position: 2, x: 1, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme := NewBeeShimmerTheme()
+			theme.ShimmerPosition = tt.position
+			if got := theme.ShimmerAt(tt.x); got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Errorf("ShimmerAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayNight_Update(t *testing.T) {
+	tests := []struct {
+		name string
+		hour int
+		want color.RGBA
+	}{
+		{name: "morning", hour: 7, want: NewBeeTheme().BackgroundColor},
+		{name: "afternoon", hour: 15, want: NewBeeTheme().BackgroundColor},
+		{name: "evening", hour: 19, want: NewBlueTheme().BackgroundColor},
+		{name: "night", hour: 2, want: NewBlueTheme().BackgroundColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme := NewDayNightTheme()
+			dayNight := theme.Animation.(*DayNight)
+			dayNight.Now = func() time.Time {
+				return time.Date(2024, time.June, 1, tt.hour, 0, 0, 0, time.Local)
+			}
+			theme.Update(0)
+			if theme.BackgroundColor != tt.want {
+				t.Errorf("BackgroundColor = %v, want %v", theme.BackgroundColor, tt.want)
+			}
+			if theme.Name != "Day & Night" {
+				t.Errorf("Name = %q, want it unchanged", theme.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package color
+
+import (
+	"image/color"
+	"math"
+)
+
+const (
+	// generatedContrastRatio is a little above MinContrastRatio so rounding to whole channels can't drop below it
+	generatedContrastRatio = MinContrastRatio + 0.2
+	lightnessStep          = 0.005
+	// lightThemeLightness is the OKLCH lightness of a base color at or above which the generated theme is light
+	lightThemeLightness = 0.65
+	hoverLightness      = 0.12 // between the hex border and the hovered hex border
+	completedLoopHue    = 145  // green, like the built-in themes
+)
+
+// GenerateTheme returns a theme built around the base color: a light theme for light base colors and a dark theme
+// for dark ones. The background is a faint tint of the base color, the hex borders are the base color and the
+// pending connections are spread around the hue circle from it, each with enough contrast to pass CheckContrast.
+func GenerateTheme(name string, base color.RGBA) *Theme {
+	baseL, baseC, baseH := RGBToOKLCH(base)
+	light := baseL >= lightThemeLightness
+	// the lightness everything moves away from the background towards
+	toward := 1.0
+	backgroundL := 0.18
+	if light {
+		toward = -1
+		backgroundL = 0.97
+	}
+
+	background := OKLCHToRGB(backgroundL, math.Min(baseC, 0.03), baseH)
+	// the darkest (or lightest) version of the base color that stands out from the background
+	// leaves as much room as possible for the connections to stand out from the borders
+	border := withContrast(backgroundL, baseC, baseH, background, toward)
+	borderL, _, _ := RGBToOKLCH(border)
+	connection := withContrast(borderL, math.Min(baseC, 0.02), baseH, border, toward)
+	pendingChroma := math.Max(baseC, 0.12)
+	var pending []color.RGBA
+	for _, degrees := range []float64{120, 180, 240} {
+		pending = append(pending, withContrast(backgroundL, pendingChroma, baseH+degrees, background, toward))
+	}
+
+	return &Theme{
+		Name:                    name,
+		BackgroundColor:         background,
+		HexBorderColor:          border,
+		ConnectionColor:         connection,
+		PendingHexBorderColor:   Lighten(border, toward*hoverLightness),
+		PendingConnectionColors: pending,
+		CompletedLoopColor:      withContrast(backgroundL, 0.15, completedLoopHue, background, toward),
+	}
+}
+
+// withContrast returns the OKLCH color moved lighter (toward 1) or darker (toward -1)
+// until it has generatedContrastRatio against other, or as far as it goes.
+// The chroma is kept as it was asked for, not as it fit in the gamut, so colors get their full chroma back
+// as they move away from white and black.
+func withContrast(l, c, h float64, other color.RGBA, toward float64) color.RGBA {
+	clr := OKLCHToRGB(l, c, h)
+	for ContrastRatio(clr, other) < generatedContrastRatio && l > 0 && l < 1 {
+		l += toward * lightnessStep
+		clr = OKLCHToRGB(l, c, h)
+	}
+	return clr
+}
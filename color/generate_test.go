@@ -0,0 +1,44 @@
+package color
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestGenerateTheme(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      string
+		wantLight bool
+	}{
+		{name: "honey", base: "#FFC50B", wantLight: true},
+		{name: "slate", base: "#53687E", wantLight: false},
+		{name: "mint", base: "#B4E2CB", wantLight: true},
+		{name: "crimson", base: "#B0122D", wantLight: false},
+		{name: "black", base: "#000000", wantLight: false},
+		{name: "white", base: "#FFFFFF", wantLight: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme := GenerateTheme(tt.name, HexToRGB(tt.base))
+			if theme.Name != tt.name {
+				t.Errorf("Name = %q, want %q", theme.Name, tt.name)
+			}
+			if light := RelativeLuminance(theme.BackgroundColor) > 0.5; light != tt.wantLight {
+				t.Errorf("light background = %v, want %v", light, tt.wantLight)
+			}
+			if warnings := theme.CheckContrast(MinContrastRatio); len(warnings) > 0 {
+				t.Errorf("CheckContrast() = %v, want no warnings", warnings)
+			}
+			colors := append([]color.RGBA{theme.CompletedLoopColor}, theme.PendingConnectionColors...)
+			for _, clr := range colors {
+				if ratio := ContrastRatio(clr, theme.BackgroundColor); ratio < MinContrastRatio {
+					t.Errorf("%v on the background has a contrast ratio of %.2f", clr, ratio)
+				}
+			}
+			if theme.PendingHexBorderColor == theme.HexBorderColor {
+				t.Errorf("PendingHexBorderColor = HexBorderColor = %v, want a hover color", theme.HexBorderColor)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package color
+
+import (
+	"image/color"
+	"math"
+)
+
+// RGBToHSL returns the hue in degrees and the saturation and lightness from 0 to 1 of an opaque color
+func RGBToHSL(clr color.RGBA) (h, s, l float64) {
+	r, g, b := float64(clr.R)/255, float64(clr.G)/255, float64(clr.B)/255
+	maxC, minC := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (maxC + minC) / 2
+	chroma := maxC - minC
+	if chroma == 0 {
+		return 0, 0, l
+	}
+	s = chroma / (1 - math.Abs(2*l-1))
+	switch maxC {
+	case r:
+		h = math.Mod((g-b)/chroma, 6)
+	case g:
+		h = (b-r)/chroma + 2
+	default:
+		h = (r-g)/chroma + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h, s, l
+}
+
+// HSLToRGB returns the opaque color with the hue in degrees and the saturation and lightness from 0 to 1
+func HSLToRGB(h, s, l float64) color.RGBA {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	chroma := (1 - math.Abs(2*l-1)) * s
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = chroma, x, 0
+	case h < 120:
+		r, g, b = x, chroma, 0
+	case h < 180:
+		r, g, b = 0, chroma, x
+	case h < 240:
+		r, g, b = 0, x, chroma
+	case h < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	m := l - chroma/2
+	channel := func(c float64) uint8 {
+		return uint8(math.Round(math.Min(math.Max(c+m, 0), 1) * 255))
+	}
+	return color.RGBA{R: channel(r), G: channel(g), B: channel(b), A: 255}
+}
+
+// RotateHue returns the color with its hue turned by degrees
+func RotateHue(clr color.RGBA, degrees float64) color.RGBA {
+	h, s, l := RGBToHSL(clr)
+	return HSLToRGB(h+degrees, s, l)
+}
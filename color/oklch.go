@@ -0,0 +1,85 @@
+package color
+
+import (
+	"image/color"
+	"math"
+)
+
+// gamutTolerance is how far outside 0 to 1 a linear channel can be and still count as in the sRGB gamut
+const gamutTolerance = 1e-4
+
+// RGBToOKLCH returns the OKLCH lightness and chroma and the hue in degrees of an opaque color.
+// OKLCH is perceptually uniform, equal steps in lightness look like equal steps to the eye whatever the hue.
+func RGBToOKLCH(clr color.RGBA) (l, c, h float64) {
+	r, g, b := linearChannel(clr.R), linearChannel(clr.G), linearChannel(clr.B)
+	lms0 := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	lms1 := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	lms2 := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+	l = 0.2104542553*lms0 + 0.7936177850*lms1 - 0.0040720468*lms2
+	a := 1.9779984951*lms0 - 2.4285922050*lms1 + 0.4505937099*lms2
+	bb := 0.0259040371*lms0 + 0.7827717662*lms1 - 0.8086757660*lms2
+	c = math.Hypot(a, bb)
+	if c < 1e-6 {
+		return l, 0, 0
+	}
+	h = math.Atan2(bb, a) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return l, c, h
+}
+
+// OKLCHToRGB returns the opaque color with the OKLCH lightness, chroma and hue in degrees,
+// colors outside the sRGB gamut lose chroma until they fit, keeping their lightness and hue
+func OKLCHToRGB(l, c, h float64) color.RGBA {
+	l = math.Min(math.Max(l, 0), 1)
+	r, g, b, ok := oklchToLinear(l, c, h)
+	if !ok {
+		low, high := 0.0, c
+		for range 20 {
+			mid := (low + high) / 2
+			if _, _, _, ok := oklchToLinear(l, mid, h); ok {
+				low = mid
+			} else {
+				high = mid
+			}
+		}
+		r, g, b, _ = oklchToLinear(l, low, h)
+	}
+	return color.RGBA{R: srgbChannel(r), G: srgbChannel(g), B: srgbChannel(b), A: 255}
+}
+
+// oklchToLinear returns the linear sRGB channels of the OKLCH color and whether they are in the gamut
+func oklchToLinear(l, c, h float64) (r, g, b float64, ok bool) {
+	a, bb := c*math.Cos(h*math.Pi/180), c*math.Sin(h*math.Pi/180)
+	lms0 := math.Pow(l+0.3963377774*a+0.2158037573*bb, 3)
+	lms1 := math.Pow(l-0.1055613458*a-0.0638541728*bb, 3)
+	lms2 := math.Pow(l-0.0894841775*a-1.2914855480*bb, 3)
+	r = 4.0767416621*lms0 - 3.3077115913*lms1 + 0.2309699292*lms2
+	g = -1.2684380046*lms0 + 2.6097574011*lms1 - 0.3413193965*lms2
+	b = -0.0041960863*lms0 - 0.7034186147*lms1 + 1.7076147010*lms2
+	inGamut := func(channel float64) bool {
+		return channel >= -gamutTolerance && channel <= 1+gamutTolerance
+	}
+	return r, g, b, inGamut(r) && inGamut(g) && inGamut(b)
+}
+
+// linearChannel converts a gamma encoded sRGB channel to linear light from 0 to 1
+func linearChannel(channel uint8) float64 {
+	c := float64(channel) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// srgbChannel converts linear light from 0 to 1 to a gamma encoded sRGB channel
+func srgbChannel(c float64) uint8 {
+	c = math.Min(math.Max(c, 0), 1)
+	if c <= 0.0031308 {
+		c *= 12.92
+	} else {
+		c = 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+	return uint8(math.Round(c * 255))
+}
@@ -0,0 +1,99 @@
+package color
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+)
+
+// pendingConnectionColorCount is how many pending connection colors a theme has, the next hex's connections take one each
+const pendingConnectionColorCount = 3
+
+// themeFile is a theme as it is written in a JSON theme file, every color is a hex code for ParseHex
+//
+//	{
+//		"name": "Mint",
+//		"backgroundColor": "#DFF5EA",
+//		"hexBorderColor": "#B4E2CB",
+//		"connectionColor": "#2F4F4F",
+//		"pendingHexBorderColor": "#7A6FAF",
+//		"pendingConnectionColors": ["#E63946", "#1D3557", "#F4A261"],
+//		"completedLoopColor": "#2A9D8F",
+//		"patterns": true
+//	}
+//
+// patterns is optional and off by default. With a baseColor every other color is optional,
+// the colors left out come from GenerateTheme:
+//
+//	{"name": "Honey", "baseColor": "#FFC50B"}
+type themeFile struct {
+	Name                    string   `json:"name"`
+	BaseColor               string   `json:"baseColor"`
+	BackgroundColor         string   `json:"backgroundColor"`
+	HexBorderColor          string   `json:"hexBorderColor"`
+	ConnectionColor         string   `json:"connectionColor"`
+	PendingHexBorderColor   string   `json:"pendingHexBorderColor"`
+	PendingConnectionColors []string `json:"pendingConnectionColors"`
+	CompletedLoopColor      string   `json:"completedLoopColor"`
+	Patterns                bool     `json:"patterns"`
+}
+
+// ParseTheme parses a JSON theme file, reporting every missing or invalid field
+func ParseTheme(data []byte) (*Theme, error) {
+	var file themeFile
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid theme file: %w", err)
+	}
+
+	var errs []error
+	theme := &Theme{}
+	generated := file.BaseColor != ""
+	if generated {
+		base, err := ParseHex(file.BaseColor)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("baseColor: %w", err))
+		}
+		theme = GenerateTheme(file.Name, base)
+	}
+	parse := func(field, hexCode string, dst *color.RGBA) {
+		if hexCode == "" {
+			if !generated {
+				errs = append(errs, fmt.Errorf("%s is missing", field))
+			}
+			return
+		}
+		clr, err := ParseHex(hexCode)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field, err))
+		}
+		*dst = clr
+	}
+	if file.Name == "" {
+		errs = append(errs, errors.New("name is missing"))
+	}
+	theme.Name = file.Name
+	theme.Patterns = file.Patterns
+	parse("backgroundColor", file.BackgroundColor, &theme.BackgroundColor)
+	parse("hexBorderColor", file.HexBorderColor, &theme.HexBorderColor)
+	parse("connectionColor", file.ConnectionColor, &theme.ConnectionColor)
+	parse("pendingHexBorderColor", file.PendingHexBorderColor, &theme.PendingHexBorderColor)
+	parse("completedLoopColor", file.CompletedLoopColor, &theme.CompletedLoopColor)
+	// a generated theme keeps its own pending colors when the file leaves them out
+	if len(file.PendingConnectionColors) != pendingConnectionColorCount && (len(file.PendingConnectionColors) > 0 || !generated) {
+		errs = append(errs, fmt.Errorf("pendingConnectionColors needs %d colors, one for each connection of the next hex, not %d", pendingConnectionColorCount, len(file.PendingConnectionColors)))
+	}
+	if len(file.PendingConnectionColors) > 0 {
+		theme.PendingConnectionColors = make([]color.RGBA, len(file.PendingConnectionColors))
+	}
+	for i, hexCode := range file.PendingConnectionColors {
+		parse(fmt.Sprintf("pendingConnectionColors[%d]", i), hexCode, &theme.PendingConnectionColors[i])
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return theme, nil
+}
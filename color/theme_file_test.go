@@ -0,0 +1,130 @@
+package color
+
+import (
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		name    string
+		hexCode string
+		want    color.RGBA
+		wantErr bool
+	}{
+		{name: "long", hexCode: "#53687E", want: color.RGBA{R: 0x53, G: 0x68, B: 0x7e, A: 255}},
+		{name: "without #", hexCode: "53687e", want: color.RGBA{R: 0x53, G: 0x68, B: 0x7e, A: 255}},
+		{name: "short", hexCode: "#F80", want: color.RGBA{R: 0xff, G: 0x88, B: 0x00, A: 255}},
+		{name: "short alpha", hexCode: "#F80F", want: color.RGBA{R: 0xff, G: 0x88, B: 0x00, A: 255}},
+		{name: "alpha is premultiplied", hexCode: "#FF000080", want: color.RGBA{R: 0x80, G: 0, B: 0, A: 0x80}},
+		{name: "transparent", hexCode: "#12345600", want: color.RGBA{}},
+		{name: "empty", hexCode: "", wantErr: true},
+		{name: "only #", hexCode: "#", wantErr: true},
+		{name: "five digits", hexCode: "#12345", wantErr: true},
+		{name: "not hex", hexCode: "#GG0000", wantErr: true},
+		{name: "sign", hexCode: "+12345", wantErr: true},
+		{name: "too long", hexCode: "#123456789", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHex(tt.hexCode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseHex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	const valid = `{
+		"name": "Mint",
+		"backgroundColor": "#DFF5EA",
+		"hexBorderColor": "#B4E2CB",
+		"connectionColor": "#2F4F4F",
+		"pendingHexBorderColor": "#7A6FAF",
+		"pendingConnectionColors": ["#E63946", "#1D3557", "#F4A261"],
+		"completedLoopColor": "#2A9D8F"
+	}`
+	tests := []struct {
+		name         string
+		data         string
+		wantPatterns bool
+		wantErrs     []string // each is part of the error message
+	}{
+		{name: "valid", data: valid},
+		{name: "patterns", data: strings.Replace(valid, `"name"`, `"patterns": true, "name"`, 1), wantPatterns: true},
+		{name: "not JSON", data: `name = "Mint"`, wantErrs: []string{"invalid theme file"}},
+		{name: "unknown field", data: strings.Replace(valid, `"name"`, `"title"`, 1), wantErrs: []string{`unknown field "title"`}},
+		{name: "missing name", data: strings.Replace(valid, `"Mint"`, `""`, 1), wantErrs: []string{"name is missing"}},
+		{
+			name:     "invalid colors",
+			data:     strings.Replace(strings.Replace(valid, "#DFF5EA", "#DFF5E", 1), "#1D3557", "blue", 1),
+			wantErrs: []string{`backgroundColor: invalid color "#DFF5E"`, `pendingConnectionColors[1]: invalid color "blue"`},
+		},
+		{
+			name:     "missing colors",
+			data:     `{"name": "Mint"}`,
+			wantErrs: []string{"backgroundColor is missing", "completedLoopColor is missing", "pendingConnectionColors needs 3 colors"},
+		},
+		{
+			name:     "one pending connection color",
+			data:     strings.Replace(valid, `"#E63946", "#1D3557", "#F4A261"`, `"#E63946"`, 1),
+			wantErrs: []string{"pendingConnectionColors needs 3 colors, one for each connection of the next hex, not 1"},
+		},
+		{
+			name:     "two pending connection colors",
+			data:     strings.Replace(valid, `"#E63946", "#1D3557", "#F4A261"`, `"#E63946", "#1D3557"`, 1),
+			wantErrs: []string{"pendingConnectionColors needs 3 colors, one for each connection of the next hex, not 2"},
+		},
+		{
+			name:     "one pending connection color with a base color",
+			data:     `{"name": "Mint", "baseColor": "#B4E2CB", "pendingConnectionColors": ["#E63946"]}`,
+			wantErrs: []string{"pendingConnectionColors needs 3 colors"},
+		},
+		{
+			name:     "two pending connection colors with a base color",
+			data:     `{"name": "Mint", "baseColor": "#B4E2CB", "pendingConnectionColors": ["#E63946", "#1D3557"]}`,
+			wantErrs: []string{"pendingConnectionColors needs 3 colors"},
+		},
+		{name: "invalid base color", data: `{"name": "Mint", "baseColor": "mint"}`, wantErrs: []string{`baseColor: invalid color "mint"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := ParseTheme([]byte(tt.data))
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("ParseTheme() error = %v", err)
+				}
+				if theme.Name != "Mint" || len(theme.PendingConnectionColors) != 3 || theme.BackgroundColor != HexToRGB("#DFF5EA") || theme.Patterns != tt.wantPatterns {
+					t.Errorf("ParseTheme() = %+v", theme)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ParseTheme() = %+v, want an error", theme)
+			}
+			for _, wantErr := range tt.wantErrs {
+				if !strings.Contains(err.Error(), wantErr) {
+					t.Errorf("ParseTheme() error = %q, want it to contain %q", err, wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTheme_baseColor(t *testing.T) {
+	theme, err := ParseTheme([]byte(`{"name": "Mint", "baseColor": "#B4E2CB", "connectionColor": "#2F4F4F"}`))
+	if err != nil {
+		t.Fatalf("ParseTheme() error = %v", err)
+	}
+	want := GenerateTheme("Mint", HexToRGB("#B4E2CB"))
+	want.ConnectionColor = HexToRGB("#2F4F4F")
+	if !reflect.DeepEqual(theme, want) {
+		t.Errorf("ParseTheme() = %+v, want %+v", theme, want)
+	}
+}
@@ -0,0 +1,126 @@
+package draw
+
+import (
+	"image"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+// BoardCache keeps the hex borders and placed connections of a board in an offscreen image
+// so that each frame only the hexes whose connections changed are redrawn
+type BoardCache struct {
+	image *ebiten.Image
+	theme color2.Theme
+	drawn map[*hexagon.Hex][]hexagon.Connection
+	valid bool
+}
+
+func NewBoardCache() *BoardCache {
+	return &BoardCache{}
+}
+
+// Invalidate forces the whole board to be redrawn on the next call to Draw
+func (this *BoardCache) Invalidate() {
+	this.valid = false
+}
+
+// Draw draws the board onto screen, redrawing the cached image where it is out of date.
+// Hexes that hidden returns true for are drawn empty so that they can be animated on top of the board.
+func (this *BoardCache) Draw(screen *ebiten.Image, hexes []*hexagon.Hex, theme *color2.Theme, hidden func(*hexagon.Hex) bool) {
+	bounds := screen.Bounds()
+	if this.image == nil || this.image.Bounds().Size() != bounds.Size() {
+		if this.image != nil {
+			this.image.Deallocate()
+		}
+		this.image = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+		this.valid = false
+	}
+	if !sameColors(&this.theme, theme) {
+		this.valid = false
+	}
+	visibleConnections := func(hex *hexagon.Hex) []hexagon.Connection {
+		if hidden != nil && hidden(hex) {
+			return nil
+		}
+		return hex.Connections
+	}
+
+	if !this.valid || this.boardChanged(hexes) {
+		this.redraw(this.image, hexes, theme, visibleConnections)
+		this.theme = *theme
+		this.valid = true
+	} else {
+		for _, hex := range hexes {
+			if !slices.Equal(this.drawn[hex], visibleConnections(hex)) {
+				region := this.image.SubImage(hexBounds(hex)).(*ebiten.Image)
+				this.redraw(region, overlappingHexes(hexes, hex), theme, visibleConnections)
+			}
+		}
+	}
+
+	this.drawn = make(map[*hexagon.Hex][]hexagon.Connection, len(hexes))
+	for _, hex := range hexes {
+		this.drawn[hex] = visibleConnections(hex)
+	}
+	screen.DrawImage(this.image, nil)
+}
+
+// boardChanged reports whether hexes aren't the hexes that were drawn last time
+func (this *BoardCache) boardChanged(hexes []*hexagon.Hex) bool {
+	if len(hexes) != len(this.drawn) {
+		return true
+	}
+	for _, hex := range hexes {
+		if _, ok := this.drawn[hex]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// redraw clears dst and draws the hexes in the same order as an uncached board,
+// all borders first and then all connections, so a region redraw matches a full one
+func (this *BoardCache) redraw(dst *ebiten.Image, hexes []*hexagon.Hex, theme *color2.Theme, visibleConnections func(*hexagon.Hex) []hexagon.Connection) {
+	dst.Clear()
+	for _, hex := range hexes {
+		Hexagon(dst, hex, theme.HexBorderColor)
+	}
+	useBatch(func(batch *ConnectionBatch) {
+		for _, hex := range hexes {
+			for _, connection := range visibleConnections(hex) {
+				batch.Add(hex, connection, theme.ConnectionColor, theme.BackgroundColor)
+			}
+		}
+		batch.Draw(dst)
+	})
+}
+
+// hexBounds returns a rectangle that contains everything drawn for the hex
+func hexBounds(hex *hexagon.Hex) image.Rectangle {
+	reach := hex.VertexRadius + float64(hex.EdgeWidth)
+	return image.Rect(
+		int(hex.Center[0]-reach)-1,
+		int(hex.Center[1]-reach)-1,
+		int(hex.Center[0]+reach)+2,
+		int(hex.Center[1]+reach)+2,
+	)
+}
+
+func overlappingHexes(hexes []*hexagon.Hex, hex *hexagon.Hex) (overlapping []*hexagon.Hex) {
+	bounds := hexBounds(hex)
+	for _, other := range hexes {
+		if hexBounds(other).Overlaps(bounds) {
+			overlapping = append(overlapping, other)
+		}
+	}
+	return overlapping
+}
+
+func sameColors(a, b *color2.Theme) bool {
+	return a.BackgroundColor == b.BackgroundColor &&
+		a.HexBorderColor == b.HexBorderColor &&
+		a.ConnectionColor == b.ConnectionColor
+}
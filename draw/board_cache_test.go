@@ -0,0 +1,122 @@
+//go:build gpu
+
+package draw
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+func drawUncachedBoard(dst *ebiten.Image, hexes []*hexagon.Hex, theme *color2.Theme) {
+	for _, hex := range hexes {
+		Hexagon(dst, hex, theme.HexBorderColor)
+	}
+	for _, hex := range hexes {
+		HexagonConnections(dst, hex, theme.ConnectionColor, theme)
+	}
+}
+
+func TestBoardCache_matchesUncachedBoard(t *testing.T) {
+	theme := color2.NewDefaultTheme()
+	hexes := newTestBoard(false)
+	cache := NewBoardCache()
+	cached := ebiten.NewImage(testImageWidth, testImageHeight)
+	cache.Draw(cached, hexes, theme, nil)
+
+	// change a few hexes so that only their regions are redrawn
+	for i, hex := range hexes {
+		if i%7 == 0 {
+			hex.Connections = testConnections
+		}
+	}
+	cached.Clear()
+	cache.Draw(cached, hexes, theme, nil)
+
+	uncached := ebiten.NewImage(testImageWidth, testImageHeight)
+	drawUncachedBoard(uncached, hexes, theme)
+
+	got := make([]byte, 4*testImageWidth*testImageHeight)
+	want := make([]byte, 4*testImageWidth*testImageHeight)
+	cached.ReadPixels(got)
+	uncached.ReadPixels(want)
+	const tolerance = 2
+	for i := range got {
+		if diff := int(got[i]) - int(want[i]); diff > tolerance || diff < -tolerance {
+			pixel := i / 4
+			t.Fatalf("pixel (%d, %d) = %v, want %v", pixel%testImageWidth, pixel/testImageWidth, got[i-i%4:i-i%4+4], want[i-i%4:i-i%4+4])
+		}
+	}
+}
+
+func TestBoardCache_invalidatedOnThemeChange(t *testing.T) {
+	hexes := newTestBoard(true)
+	cache := NewBoardCache()
+	dst := ebiten.NewImage(testImageWidth, testImageHeight)
+	cache.Draw(dst, hexes, color2.NewBeeTheme(), nil)
+
+	blueTheme := color2.NewBlueTheme()
+	dst.Clear()
+	cache.Draw(dst, hexes, blueTheme, nil)
+
+	want := ebiten.NewImage(testImageWidth, testImageHeight)
+	drawUncachedBoard(want, hexes, blueTheme)
+	center := hexes[0].HexagonSideCoordinates()[0]
+	x, y := int(center[0]), int(center[1])
+	if got, want := dst.At(x, y), want.At(x, y); got != want {
+		t.Errorf("At(%d, %d) = %v, want %v", x, y, got, want)
+	}
+}
+
+// BenchmarkBoard_uncached measures the per-frame cost of drawing a full board without the cache
+func BenchmarkBoard_uncached(b *testing.B) {
+	theme := color2.NewDefaultTheme()
+	hexes := newTestBoard(true)
+	dst := ebiten.NewImage(testImageWidth, testImageHeight)
+	b.ResetTimer()
+	for range b.N {
+		drawUncachedBoard(dst, hexes, theme)
+		flush(dst)
+	}
+}
+
+// BenchmarkBoard_cached measures the per-frame cost of drawing a full board that didn't change
+func BenchmarkBoard_cached(b *testing.B) {
+	theme := color2.NewDefaultTheme()
+	hexes := newTestBoard(true)
+	dst := ebiten.NewImage(testImageWidth, testImageHeight)
+	cache := NewBoardCache()
+	cache.Draw(dst, hexes, theme, nil)
+	b.ResetTimer()
+	for range b.N {
+		cache.Draw(dst, hexes, theme, nil)
+		flush(dst)
+	}
+}
+
+// BenchmarkBoard_cachedOneChange measures the per-frame cost of drawing a full board after placing one tile
+func BenchmarkBoard_cachedOneChange(b *testing.B) {
+	theme := color2.NewDefaultTheme()
+	hexes := newTestBoard(true)
+	dst := ebiten.NewImage(testImageWidth, testImageHeight)
+	cache := NewBoardCache()
+	cache.Draw(dst, hexes, theme, nil)
+	hex := hexes[len(hexes)/2]
+	b.ResetTimer()
+	for i := range b.N {
+		if i%2 == 0 {
+			hex.Connections = nil
+		} else {
+			hex.Connections = testConnections
+		}
+		cache.Draw(dst, hexes, theme, nil)
+		flush(dst)
+	}
+}
+
+// flush makes the GPU draw everything queued for dst, as it does once a frame, by reading a pixel back
+func flush(dst *ebiten.Image) {
+	dst.At(0, 0)
+}
@@ -2,175 +2,151 @@ package draw
 
 import (
 	"image/color"
-	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/font"
 	"github.com/tliddle1/hexloop/hexagon"
 	"github.com/tliddle1/hexloop/vector"
 )
 
+// widths at a scale of 1, the exported functions scale them for the screen
 const (
-	HexagonStrokeWidth      = 2 * hexagon.Scale
-	ConnectionWidth         = 3 * hexagon.Scale
-	TitleHexagonStrokeWidth = 4 * hexagon.Scale
-	TitleConnectionWidth    = 6 * hexagon.Scale
-	pathBuffer              = 4 * hexagon.Scale
+	hexagonStrokeWidth      = 2
+	connectionWidth         = 3
+	titleHexagonStrokeWidth = 4
+	titleConnectionWidth    = 6
 )
 
-// TODO meet corners of hexagon visually
+// HexagonStrokeWidth returns the width of a board hexagon's border at the given scale
+func HexagonStrokeWidth(scale float64) float32 {
+	return float32(hexagonStrokeWidth * scale)
+}
 
-func Hexagon(screen *ebiten.Image, hex *hexagon.Hex, borderColor color.RGBA) {
-	vertices := hex.VertexCoordinates()
-	vertices = append(vertices, vertices[0])
+// ConnectionWidth returns the width of a board connection at the given scale
+func ConnectionWidth(scale float64) float32 {
+	return float32(connectionWidth * scale)
+}
 
-	// Extension per end for a regular hexagon
-	extend := float64(hex.EdgeWidth) / (2 * math.Sqrt(3))
+// TitleHexagonStrokeWidth returns the width of a title or button hexagon's border at the given scale
+func TitleHexagonStrokeWidth(scale float64) float32 {
+	return float32(titleHexagonStrokeWidth * scale)
+}
 
+// TitleConnectionWidth returns the width of a title connection at the given scale
+func TitleConnectionWidth(scale float64) float32 {
+	return float32(titleConnectionWidth * scale)
+}
+
+// Hexagon draws the hexagon's border with mitered corners, one quad along each side between its outer and inner corners
+func Hexagon(screen *ebiten.Image, hex *hexagon.Hex, borderColor color.RGBA) {
+	outer, inner := hex.BorderCorners()
+	vertices := make([]ebiten.Vertex, 0, 2*hexagon.NumHexagonSides)
+	indices := make([]uint16, 0, 6*hexagon.NumHexagonSides)
 	for i := 0; i < hexagon.NumHexagonSides; i++ {
-		x1, y1 := vertices[i][0], vertices[i][1]
-		x2, y2 := vertices[i+1][0], vertices[i+1][1]
-
-		// Direction vector
-		dx := x2 - x1
-		dy := y2 - y1
-
-		// Normalize
-		len := math.Hypot(dx, dy)
-		ux := dx / len
-		uy := dy / len
-
-		// Extend both ends
-		x1 -= ux * extend
-		y1 -= uy * extend
-		x2 += ux * extend
-		y2 += uy * extend
-
-		vector.StrokeLine(
-			screen,
-			float32(x1),
-			float32(y1),
-			float32(x2),
-			float32(y2),
-			hex.EdgeWidth,
-			borderColor,
-			true,
+		vertices = append(vertices,
+			ebiten.Vertex{DstX: float32(outer[i][0]), DstY: float32(outer[i][1])},
+			ebiten.Vertex{DstX: float32(inner[i][0]), DstY: float32(inner[i][1])},
 		)
+		next := (i + 1) % hexagon.NumHexagonSides
+		o0, i0, o1, i1 := uint16(2*i), uint16(2*i+1), uint16(2*next), uint16(2*next+1)
+		indices = append(indices, o0, o1, i0, i0, o1, i1)
 	}
+	setVertexColors(vertices, borderColor)
+	vector.DrawVertices(screen, vertices, indices, true)
 }
 
 func TextHexagon(screen *ebiten.Image, hex *hexagon.TextHexagon, borderColor, connectionColor color.RGBA) {
 	Hexagon(screen, hex.Hex, borderColor)
-	x, y := hex.Center[0], hex.Center[1]
-	fontSize := hex.TextSize
-	face := hex.GetTextFace(fontSize)
-	// todo refactor
-	if hex.Str == "How to Play" {
-		offset := text.Advance("to", face)
-		drawOptions := &text.DrawOptions{}
-		drawOptions.ColorScale.ScaleWithColor(connectionColor)
-		// to
-		drawOptions.GeoM.Translate(x-(offset/2), y-(fontSize*2/3))
-		text.Draw(screen, "to", face, drawOptions)
-		// how
-		previousXOffset := x - (offset / 2)
-		offset = text.Advance("How", face)
-		drawOptions.GeoM.Translate(x-(offset/2)-previousXOffset, -fontSize)
-		text.Draw(screen, "How", face, drawOptions)
-		// play
-		previousXOffset = x - (offset / 2)
-		offset = text.Advance("Play", face)
-		drawOptions.GeoM.Translate(x-(offset/2)-previousXOffset, fontSize*2)
-		text.Draw(screen, "Play", face, drawOptions)
-	} else {
-		offset := text.Advance(hex.Str, face)
-		drawOptions := &text.DrawOptions{}
-		drawOptions.GeoM.Translate(x-(offset/2), y-(fontSize*2/3))
-		drawOptions.ColorScale.ScaleWithColor(connectionColor)
-		text.Draw(screen, hex.Str, face, drawOptions)
-	}
+	str, size := TextHexagonLayout(hex)
+	face := font.Face(size)
+	drawOptions := font.CenteredOptions(str, face, hex.Center[0], hex.Center[1])
+	drawOptions.ColorScale.ScaleWithColor(connectionColor)
+	text.Draw(screen, str, face, drawOptions)
 }
 
 func HexagonConnections(screen *ebiten.Image, hex *hexagon.Hex, connectionColor color.RGBA, theme *color2.Theme) {
+	ScaledHexagonConnections(screen, hex, 1, connectionColor, theme)
+}
+
+// ScaledHexagonConnections draws the hex's connections scaled about its center
+func ScaledHexagonConnections(screen *ebiten.Image, hex *hexagon.Hex, scale float64, connectionColor color.RGBA, theme *color2.Theme) {
 	if len(hex.Connections) == 0 {
 		return
 	}
-	for _, connection := range hex.Connections {
-		HexagonConnection(screen, hex, connection, connectionColor, theme.BackgroundColor)
-	}
+	useBatch(func(batch *ConnectionBatch) {
+		batch.AddHexagon(hex, scale, connectionColor, theme.BackgroundColor)
+		batch.Draw(screen)
+	})
 }
 
 func HexagonConnection(screen *ebiten.Image, hex *hexagon.Hex, connection hexagon.Connection, connectionColor, backgroundColor color.RGBA) {
-	sideA := connection[0]
-	sideB := connection[1]
-	diff := math.Abs(float64(sideA - sideB))
-	// Straight Across
-	if diff == 3 {
-		LineConnection(screen, hex, connection, hex.ConnectionWidth+pathBuffer, backgroundColor)
-		LineConnection(screen, hex, connection, hex.ConnectionWidth, connectionColor)
-		return
-	}
-	// Large Curve
-	if diff == 2 {
-		centerSide := (sideA + sideB) / 2
-		angleToSide := math.Pi*2/3 + math.Pi*1/3*float64(centerSide)
-		LargeCurveConnection(screen, hex, angleToSide, hex.ConnectionWidth+pathBuffer, backgroundColor)
-		LargeCurveConnection(screen, hex, angleToSide, hex.ConnectionWidth, connectionColor)
-		return
-	}
-	if diff == 4 {
-		oppositeCenterSide := (sideA + sideB) / 2
-		angleToSide := -math.Pi*1/3 + math.Pi*1/3*float64(oppositeCenterSide)
-		LargeCurveConnection(screen, hex, angleToSide, hex.ConnectionWidth+pathBuffer, backgroundColor)
-		LargeCurveConnection(screen, hex, angleToSide, hex.ConnectionWidth, connectionColor)
-		return
-	}
-	// Small Curve
-	if diff == 1 {
-		SmallCurveConnection(screen, hex, min(sideA, sideB), hex.ConnectionWidth+pathBuffer, backgroundColor)
-		SmallCurveConnection(screen, hex, min(sideA, sideB), hex.ConnectionWidth, connectionColor)
-		return
-	}
-	if diff == 5 {
-		SmallCurveConnection(screen, hex, 5, hex.ConnectionWidth+pathBuffer, backgroundColor)
-		SmallCurveConnection(screen, hex, 5, hex.ConnectionWidth, connectionColor)
+	useBatch(func(batch *ConnectionBatch) {
+		batch.Add(hex, connection, connectionColor, backgroundColor)
+		batch.Draw(screen)
+	})
+}
+
+// connectionPath returns the path a connection takes through the hex
+func connectionPath(hex *hexagon.Hex, connection hexagon.Connection) *vector.Path {
+	var path vector.Path
+	appendConnectionPath(&path, hex.ConnectionPath(connection))
+	return &path
+}
+
+func appendConnectionPath(path *vector.Path, connectionPath hexagon.ConnectionPath) {
+	if connectionPath.Shape == hexagon.StraightConnection {
+		path.MoveTo(float32(connectionPath.From[0]), float32(connectionPath.From[1]))
+		path.LineTo(float32(connectionPath.To[0]), float32(connectionPath.To[1]))
 		return
 	}
+	path.Arc(
+		float32(connectionPath.Center[0]),
+		float32(connectionPath.Center[1]),
+		float32(connectionPath.Radius),
+		float32(connectionPath.StartAngle),
+		float32(connectionPath.EndAngle),
+		vector.Clockwise,
+	)
 }
 
 func LineConnection(screen *ebiten.Image, hex *hexagon.Hex, connection hexagon.Connection, strokeWidth float32, connectionColor color.RGBA) {
-	sideA := connection[0]
-	sideB := connection[1]
-	sides := hex.HexagonSideCoordinates()
-	x1 := float32(sides[sideA][0])
-	y1 := float32(sides[sideA][1])
-	x2 := float32(sides[sideB][0])
-	y2 := float32(sides[sideB][1])
-	vector.StrokeLine(screen, x1, y1, x2, y2, strokeWidth, connectionColor, true)
+	var path vector.Path
+	appendConnectionPath(&path, hex.StraightPath(connection))
+	strokePath(screen, &path, strokeWidth, connectionColor)
 }
 
 func LargeCurveConnection(screen *ebiten.Image, hex *hexagon.Hex, angleToSide float64, strokeWidth float32, connectionColor color.Color) {
-	x := float32(hex.Center[0] - math.Cos(angleToSide)*hex.SideRadius*2)
-	y := float32(hex.Center[1] - math.Sin(angleToSide)*hex.SideRadius*2)
-	radius := float32(hex.VertexRadius+hex.SideRadius/2) + hex.EdgeWidth
-	startAngle := float32(angleToSide - math.Pi/6)
-	endAngle := float32(angleToSide + math.Pi/6)
-	vector.StrokePartialCircle(screen, x, y, radius, startAngle, endAngle, strokeWidth, connectionColor, true)
+	var path vector.Path
+	appendConnectionPath(&path, hex.LargeCurvePath(angleToSide))
+	strokePath(screen, &path, strokeWidth, connectionColor)
 }
 
 func SmallCurveConnection(screen *ebiten.Image, hex *hexagon.Hex, vertex int, strokeWidth float32, connectionColor color.RGBA) {
-	vertices := hex.VertexCoordinates()
-	x := float32(vertices[vertex][0])
-	y := float32(vertices[vertex][1])
-	adjustor := math.Pi / 3 * float32(vertex)
-	vector.StrokePartialCircle(screen, x, y, float32(hex.VertexRadius/2), math.Pi/2+adjustor, -math.Pi*5/6+adjustor, strokeWidth, connectionColor, true)
+	var path vector.Path
+	appendConnectionPath(&path, hex.SmallCurvePath(vertex))
+	strokePath(screen, &path, strokeWidth, connectionColor)
 }
 
-func Loops(screen *ebiten.Image, loops []hexagon.Loop, completedLoopColor, backgroundColor color.RGBA) {
-	for _, loop := range loops {
-		for _, hexConnection := range loop {
-			HexagonConnection(screen, hexConnection.Hex, hexConnection.Connection, completedLoopColor, backgroundColor)
+// Loops draws the loops' connections scaled about the centers of their hexes
+func Loops(screen *ebiten.Image, loops []hexagon.Loop, scale float64, completedLoopColor, backgroundColor color.RGBA) {
+	useBatch(func(batch *ConnectionBatch) {
+		for _, loop := range loops {
+			for _, hexConnection := range loop {
+				batch.AddScaled(hexConnection.Hex, hexConnection.Connection, scale, completedLoopColor, backgroundColor)
+			}
 		}
-	}
+		batch.Draw(screen)
+	})
+}
+
+// scaledHex returns a copy of the hex resized around its center
+func scaledHex(hex *hexagon.Hex, scale float64) *hexagon.Hex {
+	scaled := *hex
+	scaled.VertexRadius *= scale
+	scaled.SideRadius *= scale
+	scaled.EdgeWidth *= float32(scale)
+	scaled.ConnectionWidth *= float32(scale)
+	return &scaled
 }
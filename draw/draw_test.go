@@ -0,0 +1,35 @@
+package draw
+
+import (
+	"image/color"
+
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+const (
+	testRows        = 5
+	testCols        = testRows*4 - 2
+	testImageWidth  = 1100
+	testImageHeight = 1000
+	testScale       = 2
+)
+
+var (
+	testConnections     = []hexagon.Connection{{0, 3}, {1, 5}, {2, 4}}
+	testConnectionColor = color.RGBA{R: 133, G: 77, B: 13, A: 255}
+	testBackgroundColor = color.RGBA{R: 251, G: 217, B: 100, A: 255}
+)
+
+func newTestBoard(filled bool) []*hexagon.Hex {
+	var hexes []*hexagon.Hex
+	for row := 0; row < testRows; row++ {
+		for col := 0; col < testCols; col++ {
+			hex := hexagon.NewHex(col, row, hexagon.HexSideRadius*testScale, hexagon.HexVertexRadius*testScale, hexagon.HexVertexRadius*testScale, HexagonStrokeWidth(testScale), ConnectionWidth(testScale))
+			if filled {
+				hex.Connections = testConnections
+			}
+			hexes = append(hexes, hex)
+		}
+	}
+	return hexes
+}
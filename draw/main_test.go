@@ -0,0 +1,40 @@
+//go:build gpu
+
+package draw
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// testGame runs the tests inside ebiten's game loop so images can be drawn to and read back
+type testGame struct {
+	m    *testing.M
+	code int
+}
+
+func (this *testGame) Update() error {
+	this.code = this.m.Run()
+	return ebiten.Termination
+}
+
+func (this *testGame) Draw(*ebiten.Image) {
+}
+
+func (this *testGame) Layout(int, int) (int, int) {
+	return 320, 240
+}
+
+// TestMain is only built with the gpu tag, the tests that draw need a display and a GPU: go test -tags gpu ./draw
+func TestMain(m *testing.M) {
+	g := &testGame{
+		m:    m,
+		code: 1,
+	}
+	if err := ebiten.RunGame(g); err != nil {
+		panic(err)
+	}
+	os.Exit(g.code)
+}
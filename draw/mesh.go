@@ -0,0 +1,172 @@
+package draw
+
+import (
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/tliddle1/hexloop/hexagon"
+	"github.com/tliddle1/hexloop/vector"
+)
+
+// maxCachedMeshes bounds the mesh cache, which gains meshes for every hex size the window is resized to.
+// Animations don't add to it since they scale the meshes of the unscaled hexes.
+const maxCachedMeshes = 256
+
+// meshKey identifies a connection mesh. The 15 connections are the three shapes
+// (straight, large curve and small curve) in each of their rotations.
+type meshKey struct {
+	vertexRadius, sideRadius   float64
+	edgeWidth, connectionWidth float32
+	connection                 hexagon.Connection
+}
+
+type mesh struct {
+	vertices []ebiten.Vertex
+	indices  []uint16
+}
+
+// connectionMesh holds the tessellated strokes of a connection centered on (0, 0)
+type connectionMesh struct {
+	background, foreground mesh
+}
+
+var (
+	meshes  = map[meshKey]*connectionMesh{}
+	meshesM sync.Mutex
+	batch   ConnectionBatch
+	batchM  sync.Mutex
+)
+
+func useBatch(fn func(batch *ConnectionBatch)) {
+	batchM.Lock()
+	defer batchM.Unlock()
+	fn(&batch)
+}
+
+// getConnectionMesh returns the mesh for the connection in a hex the size of hex, tessellating it the first time
+func getConnectionMesh(hex *hexagon.Hex, connection hexagon.Connection) *connectionMesh {
+	// a connection is drawn the same in either direction
+	if connection[0] > connection[1] {
+		connection[0], connection[1] = connection[1], connection[0]
+	}
+	key := meshKey{
+		vertexRadius:    hex.VertexRadius,
+		sideRadius:      hex.SideRadius,
+		edgeWidth:       hex.EdgeWidth,
+		connectionWidth: hex.ConnectionWidth,
+		connection:      connection,
+	}
+	meshesM.Lock()
+	defer meshesM.Unlock()
+	if m, ok := meshes[key]; ok {
+		return m
+	}
+	if len(meshes) >= maxCachedMeshes {
+		clear(meshes)
+	}
+	origin := *hex
+	origin.Center = hexagon.Coordinate{}
+	path := connectionPath(&origin, connection)
+	m := &connectionMesh{
+		background: strokeMesh(path, hex.BufferWidth()),
+		foreground: strokeMesh(path, hex.ConnectionWidth),
+	}
+	meshes[key] = m
+	return m
+}
+
+func strokeMesh(path *vector.Path, strokeWidth float32) mesh {
+	strokeOp := &vector.StrokeOptions{}
+	strokeOp.Width = strokeWidth
+	vertices, indices := path.AppendVerticesAndIndicesForStroke(nil, nil, strokeOp)
+	return mesh{
+		vertices: vertices,
+		indices:  indices,
+	}
+}
+
+// strokePath strokes the path without caching its mesh
+func strokePath(screen *ebiten.Image, path *vector.Path, strokeWidth float32, clr color.Color) {
+	m := strokeMesh(path, strokeWidth)
+	setVertexColors(m.vertices, clr)
+	vector.DrawVertices(screen, m.vertices, m.indices, true)
+}
+
+func setVertexColors(vertices []ebiten.Vertex, clr color.Color) {
+	r, g, b, a := clr.RGBA()
+	for i := range vertices {
+		vertices[i].ColorR = float32(r) / 0xffff
+		vertices[i].ColorG = float32(g) / 0xffff
+		vertices[i].ColorB = float32(b) / 0xffff
+		vertices[i].ColorA = float32(a) / 0xffff
+	}
+}
+
+// ConnectionBatch collects connections so that they can be drawn with as few DrawTriangles calls as possible.
+// Connections are drawn in the order they were added.
+type ConnectionBatch struct {
+	chunks []mesh
+}
+
+// AddHexagon adds every connection of the hex scaled about its center to the batch
+func (this *ConnectionBatch) AddHexagon(hex *hexagon.Hex, scale float64, connectionColor, backgroundColor color.RGBA) {
+	for _, connection := range hex.Connections {
+		this.AddScaled(hex, connection, scale, connectionColor, backgroundColor)
+	}
+}
+
+// Add adds a connection translated to the center of the hex to the batch
+func (this *ConnectionBatch) Add(hex *hexagon.Hex, connection hexagon.Connection, connectionColor, backgroundColor color.RGBA) {
+	this.AddScaled(hex, connection, 1, connectionColor, backgroundColor)
+}
+
+// AddScaled adds a connection scaled about the center of the hex to the batch. The mesh of the unscaled hex is
+// scaled so that animating the scale doesn't tessellate a mesh for every frame.
+func (this *ConnectionBatch) AddScaled(hex *hexagon.Hex, connection hexagon.Connection, scale float64, connectionColor, backgroundColor color.RGBA) {
+	m := getConnectionMesh(hex, connection)
+	// the background is drawn first so that it separates this connection from ones it crosses
+	this.addMesh(&m.background, hex.Center, scale, backgroundColor)
+	this.addMesh(&m.foreground, hex.Center, scale, connectionColor)
+}
+
+func (this *ConnectionBatch) addMesh(m *mesh, center hexagon.Coordinate, scale float64, clr color.RGBA) {
+	// indices are 16 bit so start a new chunk before they would overflow
+	if len(this.chunks) == 0 || len(this.chunks[len(this.chunks)-1].vertices)+len(m.vertices) > math.MaxUint16 {
+		this.newChunk()
+	}
+	chunk := &this.chunks[len(this.chunks)-1]
+	offset := uint16(len(chunk.vertices))
+	start := len(chunk.vertices)
+	chunk.vertices = append(chunk.vertices, m.vertices...)
+	for i := start; i < len(chunk.vertices); i++ {
+		chunk.vertices[i].DstX = chunk.vertices[i].DstX*float32(scale) + float32(center[0])
+		chunk.vertices[i].DstY = chunk.vertices[i].DstY*float32(scale) + float32(center[1])
+	}
+	setVertexColors(chunk.vertices[start:], clr)
+	for _, index := range m.indices {
+		chunk.indices = append(chunk.indices, index+offset)
+	}
+}
+
+func (this *ConnectionBatch) newChunk() {
+	// reuse chunks from previous draws to avoid reallocating their vertices
+	if len(this.chunks) < cap(this.chunks) {
+		this.chunks = this.chunks[:len(this.chunks)+1]
+		return
+	}
+	this.chunks = append(this.chunks, mesh{})
+}
+
+// Draw draws the batched connections onto dst and empties the batch
+func (this *ConnectionBatch) Draw(dst *ebiten.Image) {
+	for i := range this.chunks {
+		if len(this.chunks[i].indices) > 0 {
+			vector.DrawVertices(dst, this.chunks[i].vertices, this.chunks[i].indices, true)
+		}
+		this.chunks[i].vertices = this.chunks[i].vertices[:0]
+		this.chunks[i].indices = this.chunks[i].indices[:0]
+	}
+	this.chunks = this.chunks[:0]
+}
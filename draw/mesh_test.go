@@ -0,0 +1,159 @@
+package draw
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+// tessellationTolerance is how far a stroke's vertices may stray from the ideal stroke since arcs are flattened
+const tessellationTolerance = 0.25
+
+// TestConnectionBatch_followsConnectionPath checks the batched meshes against the geometry of hexagon.ConnectionPath
+// rather than against draw's own path so that a mistake in connectionPath or its tessellation shows up
+func TestConnectionBatch_followsConnectionPath(t *testing.T) {
+	hex := hexagon.NewHex(3, 2, hexagon.HexSideRadius*testScale, hexagon.HexVertexRadius*testScale, hexagon.HexVertexRadius*testScale, HexagonStrokeWidth(testScale), ConnectionWidth(testScale))
+	for sideA := 0; sideA < hexagon.NumHexagonSides; sideA++ {
+		for sideB := sideA + 1; sideB < hexagon.NumHexagonSides; sideB++ {
+			connection := hexagon.Connection{sideA, sideB}
+			path := hex.ConnectionPath(connection)
+			var batch ConnectionBatch
+			batch.Add(hex, connection, testConnectionColor, testBackgroundColor)
+			chunk := batch.chunks[0]
+			// the background stroke is added before the foreground stroke
+			split := len(getConnectionMesh(hex, connection).background.vertices)
+			tests := []struct {
+				name     string
+				width    float32
+				vertices []ebiten.Vertex
+			}{
+				{"background", hex.BufferWidth(), chunk.vertices[:split]},
+				{"foreground", hex.ConnectionWidth, chunk.vertices[split:]},
+			}
+			for _, test := range tests {
+				halfWidth := float64(test.width) / 2
+				for i, vertex := range test.vertices {
+					// a vertex is either on the edge of the stroke or, where segments are joined, on the path
+					distance := distanceToPath(path, float64(vertex.DstX), float64(vertex.DstY))
+					if math.Abs(distance-halfWidth) > tessellationTolerance && distance > tessellationTolerance {
+						t.Fatalf("connection %v %s vertex %d is %v from the path, want 0 or %v", connection, test.name, i, distance, halfWidth)
+					}
+				}
+			}
+			for _, step := range []float64{0, 0.1, 0.25, 0.5, 0.75, 0.9, 1} {
+				point := pointOnPath(path, step)
+				if !meshContains(chunk.vertices, chunk.indices, split, point) {
+					t.Fatalf("connection %v does not cover %v, %v along its path", connection, point, step)
+				}
+			}
+		}
+	}
+}
+
+func TestConnectionBatch_reversedConnectionSharesMesh(t *testing.T) {
+	hex := hexagon.NewHex(0, 0, 0, 0, hexagon.HexVertexRadius*testScale, HexagonStrokeWidth(testScale), ConnectionWidth(testScale))
+	if getConnectionMesh(hex, hexagon.Connection{1, 4}) != getConnectionMesh(hex, hexagon.Connection{4, 1}) {
+		t.Errorf("getConnectionMesh() returned different meshes for the same connection")
+	}
+}
+
+func TestConnectionBatch_AddScaled(t *testing.T) {
+	tests := []struct {
+		name  string
+		scale float64
+	}{
+		{"shrunk", 0.25},
+		{"unscaled", 1},
+		{"grown", 1.5},
+	}
+	hex := hexagon.NewHex(3, 2, hexagon.HexSideRadius*testScale, hexagon.HexVertexRadius*testScale, hexagon.HexVertexRadius*testScale, HexagonStrokeWidth(testScale), ConnectionWidth(testScale))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var unscaled, scaled ConnectionBatch
+			unscaled.Add(hex, hexagon.Connection{0, 2}, testConnectionColor, testBackgroundColor)
+			scaled.AddScaled(hex, hexagon.Connection{0, 2}, test.scale, testConnectionColor, testBackgroundColor)
+			for i, vertex := range scaled.chunks[0].vertices {
+				wantX := hex.Center[0] + (float64(unscaled.chunks[0].vertices[i].DstX)-hex.Center[0])*test.scale
+				wantY := hex.Center[1] + (float64(unscaled.chunks[0].vertices[i].DstY)-hex.Center[1])*test.scale
+				if math.Abs(float64(vertex.DstX)-wantX) > 1e-3 || math.Abs(float64(vertex.DstY)-wantY) > 1e-3 {
+					t.Fatalf("vertex %d = (%v, %v), want (%v, %v)", i, vertex.DstX, vertex.DstY, wantX, wantY)
+				}
+			}
+		})
+	}
+}
+
+func TestConnectionBatch_AddScaledReusesMesh(t *testing.T) {
+	clear(meshes)
+	hex := hexagon.NewHex(0, 0, hexagon.HexSideRadius*testScale, hexagon.HexVertexRadius*testScale, hexagon.HexVertexRadius*testScale, HexagonStrokeWidth(testScale), ConnectionWidth(testScale))
+	var batch ConnectionBatch
+	// an animation scales the hex a little more every frame
+	for frame := 0; frame <= 60; frame++ {
+		batch.AddScaled(hex, hexagon.Connection{1, 4}, float64(frame)/60, testConnectionColor, testBackgroundColor)
+	}
+	if len(meshes) != 1 {
+		t.Errorf("len(meshes) = %d, want 1", len(meshes))
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// distanceToPath returns how far (x, y) is from the closest point of the path
+func distanceToPath(path hexagon.ConnectionPath, x, y float64) float64 {
+	if path.Shape == hexagon.StraightConnection {
+		dx, dy := path.To[0]-path.From[0], path.To[1]-path.From[1]
+		step := ((x-path.From[0])*dx + (y-path.From[1])*dy) / (dx*dx + dy*dy)
+		step = max(0, min(1, step))
+		return math.Hypot(x-path.From[0]-step*dx, y-path.From[1]-step*dy)
+	}
+	angle := math.Mod(math.Atan2(y-path.Center[1], x-path.Center[0])-path.StartAngle, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	if angle <= path.Sweep() {
+		return math.Abs(math.Hypot(x-path.Center[0], y-path.Center[1]) - path.Radius)
+	}
+	return min(math.Hypot(x-path.From[0], y-path.From[1]), math.Hypot(x-path.To[0], y-path.To[1]))
+}
+
+// pointOnPath returns the point step of the way along the path, from 0 at From to 1 at To
+func pointOnPath(path hexagon.ConnectionPath, step float64) hexagon.Coordinate {
+	if path.Shape == hexagon.StraightConnection {
+		return hexagon.Coordinate{
+			path.From[0] + step*(path.To[0]-path.From[0]),
+			path.From[1] + step*(path.To[1]-path.From[1]),
+		}
+	}
+	angle := path.StartAngle + step*path.Sweep()
+	return hexagon.Coordinate{path.Center[0] + path.Radius*math.Cos(angle), path.Center[1] + path.Radius*math.Sin(angle)}
+}
+
+// meshContains reports whether a triangle whose vertices start at firstVertex contains the point
+func meshContains(vertices []ebiten.Vertex, indices []uint16, firstVertex int, point hexagon.Coordinate) bool {
+	const tolerance = 1e-3
+	for i := 0; i+2 < len(indices); i += 3 {
+		if int(indices[i]) < firstVertex {
+			continue
+		}
+		a, b, c := vertices[indices[i]], vertices[indices[i+1]], vertices[indices[i+2]]
+		if math.Abs(side(a, b, hexagon.Coordinate{float64(c.DstX), float64(c.DstY)})) < tolerance {
+			continue
+		}
+		d1 := side(a, b, point)
+		d2 := side(b, c, point)
+		d3 := side(c, a, point)
+		hasNegative := d1 < -tolerance || d2 < -tolerance || d3 < -tolerance
+		hasPositive := d1 > tolerance || d2 > tolerance || d3 > tolerance
+		if !(hasNegative && hasPositive) {
+			return true
+		}
+	}
+	return false
+}
+
+// side returns which side of the line from a to b the point is on
+func side(a, b ebiten.Vertex, point hexagon.Coordinate) float64 {
+	return (point[0]-float64(b.DstX))*float64(a.DstY-b.DstY) - float64(a.DstX-b.DstX)*(point[1]-float64(b.DstY))
+}
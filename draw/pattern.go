@@ -0,0 +1,65 @@
+package draw
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/tliddle1/hexloop/hexagon"
+	"github.com/tliddle1/hexloop/vector"
+)
+
+// Pattern is how a connection is stroked so paths can be told apart without relying on color
+type Pattern uint8
+
+const (
+	SolidPattern Pattern = iota
+	DashedPattern
+	DottedPattern
+)
+
+// PendingPatterns are the patterns of the pending tile's paths, in the order of the theme's pending colors
+var PendingPatterns = []Pattern{SolidPattern, DashedPattern, DottedPattern}
+
+// loopOutlineScale is the width of a completed loop's outline relative to the connection
+const loopOutlineScale = 2
+
+// style returns the stroke style of the pattern for a connection of the given width
+func (this Pattern) style(width float32) *vector.StrokeStyle {
+	style := &vector.StrokeStyle{AntiAlias: true}
+	style.Width = width
+	switch this {
+	case DashedPattern:
+		style.Dash = []float32{width * 3, width * 2}
+	case DottedPattern:
+		// short dashes with round caps look like dots one connection wide
+		style.LineCap = vector.LineCapRound
+		style.Dash = []float32{width / 4, width * 2}
+	}
+	return style
+}
+
+// PatternedConnection draws the connection like HexagonConnection with the connection stroked in the pattern,
+// the gap under it stays solid so the path reads as one line
+func PatternedConnection(screen *ebiten.Image, hex *hexagon.Hex, connection hexagon.Connection, pattern Pattern, connectionColor, backgroundColor color.RGBA) {
+	if pattern == SolidPattern {
+		HexagonConnection(screen, hex, connection, connectionColor, backgroundColor)
+		return
+	}
+	path := connectionPath(hex, connection)
+	strokePath(screen, path, hex.BufferWidth(), backgroundColor)
+	vector.StrokePath(screen, path, connectionColor, pattern.style(hex.ConnectionWidth))
+}
+
+// OutlinedLoops draws the loops like Loops with an outline around each connection
+// so completed loops stand apart from other connections without relying on color
+func OutlinedLoops(screen *ebiten.Image, loops []hexagon.Loop, scale float64, completedLoopColor, outlineColor, backgroundColor color.RGBA) {
+	for _, loop := range loops {
+		for _, hexConnection := range loop {
+			hex := scaledHex(hexConnection.Hex, scale)
+			path := connectionPath(hex, hexConnection.Connection)
+			strokePath(screen, path, hex.BufferWidth(), backgroundColor)
+			strokePath(screen, path, hex.ConnectionWidth*loopOutlineScale, outlineColor)
+			strokePath(screen, path, hex.ConnectionWidth, completedLoopColor)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package draw
+
+import (
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/tliddle1/hexloop/font"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+const maxCachedLayouts = 256
+
+type textLayoutKey struct {
+	str                  string
+	textSize, sideRadius float64
+}
+
+type textLayout struct {
+	str  string // str with line breaks added
+	size float64
+}
+
+var (
+	textLayouts  = map[textLayoutKey]textLayout{}
+	textLayoutsM sync.Mutex
+)
+
+// faceMeasurer measures text with the faces from the font package
+type faceMeasurer struct{}
+
+func (faceMeasurer) Advance(str string, size float64) float64 {
+	return text.Advance(str, font.Face(size))
+}
+
+func (faceMeasurer) LineHeight(size float64) float64 {
+	return font.LineHeight(font.Face(size))
+}
+
+// TextHexagonLayout wraps the hexagon's text to fit inside it, shrinking the text from TextSize until it fits
+func TextHexagonLayout(hex *hexagon.TextHexagon) (str string, size float64) {
+	key := textLayoutKey{str: hex.Str, textSize: hex.TextSize, sideRadius: hex.SideRadius}
+	textLayoutsM.Lock()
+	defer textLayoutsM.Unlock()
+	if layout, ok := textLayouts[key]; ok {
+		return layout.str, layout.size
+	}
+	if len(textLayouts) >= maxCachedLayouts {
+		clear(textLayouts)
+	}
+	str, size = hex.FitText(faceMeasurer{})
+	textLayouts[key] = textLayout{str: str, size: size}
+	return str, size
+}
@@ -0,0 +1,42 @@
+package draw
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/tliddle1/hexloop/font"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+func TestTextHexagonLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		str      string
+		textSize float64
+		maxLines int
+	}{
+		{"short label keeps its size", "Start", 24, 1},
+		{"two words wrap", "Time Attack", 24, 2},
+		{"three words wrap", "How to Play", 24, 3},
+		{"long label shrinks", "Supercalifragilistic", 24, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hex := hexagon.NewTextHexagon(0, 0, 0, 0, hexagon.HexVertexRadiusTest, 1, 1, tt.str, tt.textSize)
+			str, size := TextHexagonLayout(hex)
+			lines := strings.Split(str, "\n")
+			if len(lines) > tt.maxLines {
+				t.Errorf("TextHexagonLayout() = %q, want at most %d lines", str, tt.maxLines)
+			}
+			if size > tt.textSize {
+				t.Errorf("TextHexagonLayout() size = %v, larger than TextSize %v", size, tt.textSize)
+			}
+			for _, line := range lines {
+				if width := text.Advance(line, font.Face(size)); width > 2*hex.SideRadius {
+					t.Errorf("line %q is %v wide, wider than the hexagon", line, width)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package font
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// DefaultFont is the name the bundled MPlus font is registered under
+const DefaultFont = "mplus"
+
+// maxCachedFaces bounds the face cache since resizing the window changes every text size
+const maxCachedFaces = 256
+
+type faceKey struct {
+	name string
+	size float64
+}
+
+// Manager loads each font once and caches its faces by size
+type Manager struct {
+	m        sync.Mutex
+	sources  map[string]*text.GoTextFaceSource
+	faces    map[faceKey]text.Face
+	fontName string // font returned by Face
+}
+
+// NewManager returns a manager with the bundled MPlus font registered and in use
+func NewManager() *Manager {
+	manager := &Manager{
+		sources:  map[string]*text.GoTextFaceSource{},
+		faces:    map[faceKey]text.Face{},
+		fontName: DefaultFont,
+	}
+	if err := manager.Register(DefaultFont, fonts.MPlus1pRegular_ttf); err != nil {
+		panic(err)
+	}
+	return manager
+}
+
+// Register parses a TrueType or OpenType font so it can be used by name
+func (this *Manager) Register(name string, data []byte) error {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("font %q: %w", name, err)
+	}
+	this.m.Lock()
+	defer this.m.Unlock()
+	this.sources[name] = source
+	for key := range this.faces {
+		if key.name == name {
+			delete(this.faces, key)
+		}
+	}
+	return nil
+}
+
+// Use sets the registered font returned by Face
+func (this *Manager) Use(name string) error {
+	this.m.Lock()
+	defer this.m.Unlock()
+	if _, ok := this.sources[name]; !ok {
+		return fmt.Errorf("font %q is not registered", name)
+	}
+	this.fontName = name
+	return nil
+}
+
+// Face returns the font in use at the given size
+func (this *Manager) Face(size float64) text.Face {
+	this.m.Lock()
+	defer this.m.Unlock()
+	key := faceKey{name: this.fontName, size: size}
+	if face, ok := this.faces[key]; ok {
+		return face
+	}
+	if len(this.faces) >= maxCachedFaces {
+		clear(this.faces)
+	}
+	face := &text.GoTextFace{
+		Source: this.sources[this.fontName],
+		Size:   size,
+	}
+	this.faces[key] = face
+	return face
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var shared = NewManager()
+
+// Register adds a font to the shared manager
+func Register(name string, data []byte) error {
+	return shared.Register(name, data)
+}
+
+// Use sets the font the shared manager draws text with
+func Use(name string) error {
+	return shared.Use(name)
+}
+
+// Face returns the shared manager's font at the given size
+func Face(size float64) text.Face {
+	return shared.Face(size)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LineHeight returns the distance between the baselines of two lines of text
+func LineHeight(face text.Face) float64 {
+	metrics := face.Metrics()
+	return metrics.HAscent + metrics.HDescent + metrics.HLineGap
+}
+
+// Size returns the width and height of str, which may have several lines
+func Size(str string, face text.Face) (width, height float64) {
+	return text.Measure(str, face, LineHeight(face))
+}
+
+// CenteredOptions returns draw options that center str on (x, y), centering each line when there are several
+func CenteredOptions(str string, face text.Face, x, y float64) *text.DrawOptions {
+	_, height := Size(str, face)
+	drawOptions := &text.DrawOptions{}
+	drawOptions.GeoM.Translate(x, y-height/2)
+	drawOptions.LineSpacing = LineHeight(face)
+	drawOptions.PrimaryAlign = text.AlignCenter
+	return drawOptions
+}
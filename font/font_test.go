@@ -0,0 +1,53 @@
+package font
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
+)
+
+func TestManager_Face(t *testing.T) {
+	manager := NewManager()
+	if manager.Face(24) != manager.Face(24) {
+		t.Errorf("Face() should return the cached face for the same size")
+	}
+	if manager.Face(24) == manager.Face(48) {
+		t.Errorf("Face() should return different faces for different sizes")
+	}
+}
+
+func TestManager_Register(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"valid font", fonts.MPlus1pRegular_ttf, false},
+		{"not a font", []byte("not a font"), true},
+		{"empty", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewManager().Register(tt.name, tt.data); (err != nil) != tt.wantErr {
+				t.Errorf("Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_Use(t *testing.T) {
+	manager := NewManager()
+	if err := manager.Use("missing"); err == nil {
+		t.Errorf("Use() of an unregistered font should fail")
+	}
+	if err := manager.Register("custom", fonts.MPlus1pRegular_ttf); err != nil {
+		t.Fatal(err)
+	}
+	defaultFace := manager.Face(24)
+	if err := manager.Use("custom"); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if manager.Face(24) == defaultFace {
+		t.Errorf("Face() should use the custom font after Use()")
+	}
+}
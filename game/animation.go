@@ -0,0 +1,167 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/tliddle1/hexloop/animation"
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/draw"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+const (
+	completedLoopTicks = 50  // how long completed loops are shown when animations are disabled
+	dropScale          = 1.4 // size of a tile when it starts dropping into place
+	pulseLength        = 3   // number of connections lit up by the loop pulse
+	pulseWidthScale    = 1.5
+)
+
+var white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+
+// AnimationOptions controls how tiles and loops are animated
+type AnimationOptions struct {
+	Enabled        bool
+	PlacementTicks int // tile dropping into place
+	LoopPulseTicks int // light travelling along completed loops
+	ClearTicks     int // completed tiles shrinking and fading away
+}
+
+func DefaultAnimationOptions() AnimationOptions {
+	return AnimationOptions{
+		Enabled:        true,
+		PlacementTicks: 12,
+		LoopPulseTicks: 30,
+		ClearTicks:     20,
+	}
+}
+
+func (this *Game) SetAnimationOptions(options AnimationOptions) {
+	this.animationOptions = options
+	this.resetAnimations()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (this *Game) startPlacementAnimation(hex *hexagon.Hex) {
+	if !this.animationOptions.Enabled {
+		return
+	}
+	this.placedHex = hex
+	this.placementTween = animation.NewTween(this.animationOptions.PlacementTicks, animation.EaseOutBack)
+}
+
+// startLoopAnimation starts animating the completed loops and returns how long to wait before removing them,
+// at least a tick so they are removed even when the animations take no time
+func (this *Game) startLoopAnimation() (disabledTicks int) {
+	if !this.animationOptions.Enabled {
+		return completedLoopTicks
+	}
+	this.loopPulseTween = animation.NewTween(this.animationOptions.LoopPulseTicks, animation.Linear)
+	this.clearTween = animation.NewTween(this.animationOptions.ClearTicks, animation.EaseInCubic)
+	this.clearingHexes = loopHexes(this.loops)
+	return max(1, this.animationOptions.LoopPulseTicks+this.animationOptions.ClearTicks)
+}
+
+func (this *Game) updateAnimations() {
+	if this.placementTween != nil {
+		this.placementTween.Update()
+		if this.placementTween.Done() {
+			this.placementTween = nil
+			this.placedHex = nil
+		}
+	}
+	if this.loopPulseTween != nil && !this.loopPulseTween.Done() {
+		this.loopPulseTween.Update()
+	} else if this.clearTween != nil {
+		this.clearTween.Update()
+	}
+}
+
+func (this *Game) resetAnimations() {
+	this.placedHex = nil
+	this.placementTween = nil
+	this.loopPulseTween = nil
+	this.clearTween = nil
+	this.clearingHexes = nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (this *Game) drawPlacementAnimation(screen *ebiten.Image) {
+	if this.placementTween == nil {
+		return
+	}
+	scale := animation.Lerp(dropScale, 1, this.placementTween.Value())
+	clr := color2.Lerp(this.theme.BackgroundColor, this.theme.ConnectionColor, this.placementTween.Progress())
+	draw.ScaledHexagonConnections(screen, this.placedHex, scale, clr, this.theme)
+}
+
+func (this *Game) drawClearingHexagons(screen *ebiten.Image) {
+	progress := this.clearTween.Value()
+	if progress >= 1 {
+		return
+	}
+	clr := color2.Lerp(this.theme.ConnectionColor, this.theme.BackgroundColor, progress)
+	for hex := range this.clearingHexes {
+		draw.ScaledHexagonConnections(screen, hex, 1-progress, clr, this.theme)
+	}
+}
+
+func (this *Game) drawClearingLoops(screen *ebiten.Image) {
+	progress := this.clearTween.Value()
+	if progress >= 1 {
+		return
+	}
+	drawFadedLoops(screen, this.loops, this.theme, progress, 1-progress)
+}
+
+// drawLoopPulse draws a light travelling along each completed loop in the order its connections were found
+func (this *Game) drawLoopPulse(screen *ebiten.Image) {
+	if this.loopPulseTween == nil || this.loopPulseTween.Done() {
+		return
+	}
+	for _, loop := range this.loops {
+		head := int(this.loopPulseTween.Value() * float64(len(loop)))
+		for i := range pulseLength {
+			index := head - i
+			if index < 0 || index >= len(loop) {
+				continue
+			}
+			brightness := 1 - float64(i)/pulseLength
+			clr := color2.Lerp(this.theme.CompletedLoopColor, white, brightness*0.8)
+			hex := *loop[index].Hex
+			hex.ConnectionWidth *= pulseWidthScale
+			draw.HexagonConnection(screen, &hex, loop[index].Connection, clr, this.theme.BackgroundColor)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// animating reports whether the hex is being drawn by an animation instead of with the rest of the board
+func (this *Game) animating(hex *hexagon.Hex) bool {
+	if this.placementTween != nil && hex == this.placedHex {
+		return true
+	}
+	if this.clearing() {
+		_, ok := this.clearingHexes[hex]
+		return ok
+	}
+	return false
+}
+
+func (this *Game) clearing() bool {
+	return this.clearTween != nil && this.loopPulseTween.Done() && len(this.loops) > 0
+}
+
+// loopHexes returns the set of hexes the loops go through
+func loopHexes(loops []hexagon.Loop) map[*hexagon.Hex]struct{} {
+	hexes := map[*hexagon.Hex]struct{}{}
+	for _, loop := range loops {
+		for _, hexConnection := range loop {
+			hexes[hexConnection.Hex] = struct{}{}
+		}
+	}
+	return hexes
+}
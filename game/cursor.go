@@ -0,0 +1,125 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/tliddle1/hexloop/draw"
+	"github.com/tliddle1/hexloop/input"
+)
+
+// cursorMoves move the cursor a row or column at a time, zigzagging between the two halves of a row
+var cursorMoves = []struct {
+	action   input.Action
+	row, col int
+}{
+	{input.CursorUp, -1, 0},
+	{input.CursorDown, 1, 0},
+	{input.CursorLeft, 0, -1},
+	{input.CursorRight, 0, 1},
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// drawCursor outlines the cursor when it is on a placed tile,
+// on an empty hex the pending tile already shows where it is
+func (this *Game) drawCursor(screen *ebiten.Image) {
+	if !this.cursorActive {
+		return
+	}
+	if hex := this.getHexFromGridPosition(this.cursorRow, this.cursorCol); hex != nil && !hex.Empty() {
+		draw.Hexagon(screen, hex, this.theme.PendingHexBorderColor)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// updateCursor moves the cursor across the sides of the hex or a row or column at a time and places the tile on it,
+// it reports whether the cursor is in control of the board so the mouse doesn't move the pending tile
+func (this *Game) updateCursor() bool {
+	if this.input.MouseMoved() {
+		this.cursorActive = false
+	}
+	for side := range 6 {
+		if this.input.Repeated(input.HexSide(side)) {
+			this.moveCursor(getBorderHexGridPosition(this.cursorRow, this.cursorCol, side))
+		}
+	}
+	for _, direction := range cursorMoves {
+		if this.input.Repeated(direction.action) {
+			this.moveCursor(this.cursorRow+direction.row, this.cursorCol+direction.col)
+		}
+	}
+	if !this.cursorActive {
+		return false
+	}
+
+	cursor := this.getHexFromGridPosition(this.cursorRow, this.cursorCol)
+	for _, hex := range this.hexes {
+		hex.Hovered = hex == cursor && hex.Empty()
+	}
+	if this.input.JustPressed(input.Place) && cursor != nil && cursor.Empty() {
+		this.placeTile(cursor)
+	}
+	return true
+}
+
+// moveCursor moves the cursor to the grid position if there is a hex there.
+// The first move only shows the cursor, on the hovered hex or in the middle of the board.
+func (this *Game) moveCursor(row, col int) {
+	if !this.cursorActive {
+		this.cursorActive = true
+		this.cursorRow, this.cursorCol = rows/2, cols/2
+		if hex := this.getHoveredHex(); hex != nil {
+			this.cursorRow, this.cursorCol = hex.Row, hex.Col
+		}
+		return
+	}
+	if this.getHexFromGridPosition(row, col) != nil {
+		this.cursorRow, this.cursorCol = row, col
+	}
+}
+
+// updateFocus moves the focus between the controls with Next and Previous and hovers the focused one,
+// it reports whether Place pressed it. hovered are the controls' hovered flags in Tab order.
+func (this *Game) updateFocus(hovered ...*bool) (pressed bool) {
+	if this.input.MouseMoved() {
+		this.focus = -1
+	}
+	step := 0
+	if this.input.Repeated(input.Next) {
+		step = 1
+	} else if this.input.Repeated(input.Previous) {
+		step = -1
+	}
+	if step != 0 {
+		this.focus = nextFocus(this.focus, step, len(hovered))
+	}
+	if this.focus < 0 || this.focus >= len(hovered) {
+		return false
+	}
+	for i, h := range hovered {
+		*h = i == this.focus
+	}
+	return this.input.JustPressed(input.Place)
+}
+
+// resetFocus takes the keyboard focus off the controls, when they are about to change
+func (this *Game) resetFocus() {
+	this.focus = -1
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// nextFocus returns the control step away from focus out of n, wrapping around,
+// with nothing focused Tab starts from the first control and Shift+Tab from the last
+func nextFocus(focus, step, n int) int {
+	if n == 0 {
+		return -1
+	}
+	if focus < 0 || focus >= n {
+		if step > 0 {
+			return 0
+		}
+		return n - 1
+	}
+	return ((focus+step)%n + n) % n
+}
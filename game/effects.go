@@ -0,0 +1,183 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/tliddle1/hexloop/animation"
+	"github.com/tliddle1/hexloop/font"
+	"github.com/tliddle1/hexloop/hexagon"
+	"github.com/tliddle1/hexloop/vector"
+)
+
+const (
+	popupTicks            = 60
+	popupRise             = marginSize * 2 // how far a popup floats up before it disappears
+	particleTicks         = 40
+	particlesPerSegment   = 2
+	particleSpeed         = 1.5
+	particleRadius        = 3
+	particleGravity       = 0.04
+	celebrationTicks      = 120
+	celebrationParticles  = 120
+	celebrationSparkHexes = 12
+)
+
+type scorePopup struct {
+	str      string
+	x, y     float64
+	textSize float64
+	clr      color.RGBA
+	tween    *animation.Tween
+}
+
+type particle struct {
+	x, y, vx, vy float64
+	radius       float32
+	clr          color.RGBA
+	tween        *animation.Tween
+}
+
+// effects is a layer of short-lived popups and particles drawn on top of the board
+type effects struct {
+	popups      []*scorePopup
+	particles   []*particle
+	layoutScale float64 // screen pixels per design pixel
+}
+
+func (this *effects) addScorePopup(points int, x, y, textSize float64, clr color.RGBA, ticks int) {
+	this.popups = append(this.popups, &scorePopup{
+		str:      "+" + withCommas(strconv.Itoa(points)),
+		x:        x,
+		y:        y,
+		textSize: textSize,
+		clr:      clr,
+		tween:    animation.NewTween(ticks, animation.EaseOutCubic),
+	})
+}
+
+// addLoopParticles emits particles from the middle of each side the loop passes through
+func (this *effects) addLoopParticles(loop hexagon.Loop, clr color.RGBA) {
+	for _, hexConnection := range loop {
+		side := hexConnection.Hex.HexagonSideCoordinates()[hexConnection.Connection[0]]
+		for range particlesPerSegment {
+			this.addParticle(side[0], side[1], particleSpeed, clr, particleTicks)
+		}
+	}
+}
+
+// addBurst emits particles in every direction from (x, y) using a random color from colors
+func (this *effects) addBurst(x, y float64, count int, speed float64, colors []color.RGBA, ticks int) {
+	for range count {
+		this.addParticle(x, y, speed, colors[rand.Intn(len(colors))], ticks)
+	}
+}
+
+func (this *effects) addParticle(x, y, speed float64, clr color.RGBA, ticks int) {
+	angle := rand.Float64() * 2 * math.Pi
+	speed *= (0.5 + rand.Float64()) * this.layoutScale
+	this.particles = append(this.particles, &particle{
+		x:      x,
+		y:      y,
+		vx:     math.Cos(angle) * speed,
+		vy:     math.Sin(angle) * speed,
+		radius: float32(particleRadius * this.layoutScale),
+		clr:    clr,
+		tween:  animation.NewTween(ticks, animation.Linear),
+	})
+}
+
+func (this *effects) update() {
+	popups := this.popups[:0]
+	for _, popup := range this.popups {
+		popup.tween.Update()
+		if !popup.tween.Done() {
+			popups = append(popups, popup)
+		}
+	}
+	this.popups = popups
+
+	particles := this.particles[:0]
+	for _, p := range this.particles {
+		p.tween.Update()
+		p.x += p.vx
+		p.y += p.vy
+		p.vy += particleGravity * this.layoutScale
+		if !p.tween.Done() {
+			particles = append(particles, p)
+		}
+	}
+	this.particles = particles
+}
+
+func (this *effects) draw(screen *ebiten.Image) {
+	for _, p := range this.particles {
+		progress := p.tween.Value()
+		alpha := uint8(255 * (1 - progress))
+		radius := p.radius * float32(1-progress/2)
+		vector.DrawFilledCircle(screen, float32(p.x), float32(p.y), radius, transparent(p.clr, alpha), true)
+	}
+	for _, popup := range this.popups {
+		progress := popup.tween.Value()
+		drawOptions := &text.DrawOptions{}
+		drawOptions.GeoM.Translate(popup.x, popup.y-popupRise*this.layoutScale*progress)
+		drawOptions.ColorScale.ScaleWithColor(popup.clr)
+		drawOptions.ColorScale.ScaleAlpha(float32(1 - popup.tween.Progress()))
+		drawOptions.PrimaryAlign = text.AlignCenter
+		drawOptions.SecondaryAlign = text.AlignCenter
+		text.Draw(screen, popup.str, font.Face(popup.textSize), drawOptions)
+	}
+}
+
+func (this *effects) clear() {
+	this.popups = nil
+	this.particles = nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// addLoopEffects shows the points each loop earned where it was completed
+func (this *Game) addLoopEffects(loops []hexagon.Loop, multiplier int) {
+	for _, loop := range loops {
+		x, y := loopCentroid(loop)
+		points := loopPointFormula(len(loop)) * len(loops) * multiplier
+		this.effects.addScorePopup(points, x, y, this.px(smallTextSize), this.theme.ConnectionColor, popupTicks)
+		if this.animationOptions.Enabled {
+			this.effects.addLoopParticles(loop, this.theme.CompletedLoopColor)
+		}
+	}
+}
+
+func (this *Game) addClearBoardEffects() {
+	x, y := this.boardCenter()
+	this.effects.addScorePopup(clearBoardBonus, x, y, this.px(smallTextSize*2), this.theme.ConnectionColor, celebrationTicks)
+	if !this.animationOptions.Enabled {
+		return
+	}
+	colors := append([]color.RGBA{this.theme.CompletedLoopColor}, this.theme.PendingConnectionColors...)
+	this.effects.addBurst(x, y, celebrationParticles, particleSpeed*2, colors, celebrationTicks)
+	for range celebrationSparkHexes {
+		hex := this.hexes[rand.Intn(len(this.hexes))]
+		this.effects.addBurst(hex.Center[0], hex.Center[1], celebrationParticles/celebrationSparkHexes, particleSpeed, colors, celebrationTicks/2)
+	}
+}
+
+func (this *Game) boardCenter() (x, y float64) {
+	for _, hex := range this.hexes {
+		x += hex.Center[0]
+		y += hex.Center[1]
+	}
+	return x / float64(len(this.hexes)), y / float64(len(this.hexes))
+}
+
+func loopCentroid(loop hexagon.Loop) (x, y float64) {
+	for _, hexConnection := range loop {
+		x += hexConnection.Hex.Center[0]
+		y += hexConnection.Hex.Center[1]
+	}
+	return x / float64(len(loop)), y / float64(len(loop))
+}
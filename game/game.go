@@ -1,33 +1,50 @@
 package game
 
 import (
-	"bytes"
+	"errors"
 	"image/color"
+	"io"
 	"log"
+	"math"
 	"math/rand"
+	"os"
 	"strconv"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/tliddle1/hexloop/animation"
 	color2 "github.com/tliddle1/hexloop/color"
 	"github.com/tliddle1/hexloop/draw"
+	"github.com/tliddle1/hexloop/font"
 	"github.com/tliddle1/hexloop/hexagon"
+	"github.com/tliddle1/hexloop/input"
+	"github.com/tliddle1/hexloop/svg"
 	"github.com/tliddle1/hexloop/vector"
 )
 
 const (
 	// board
-	scale         = hexagon.Scale
 	rows          = 5          // Number of hexagon rows
 	cols          = rows*4 - 2 // Number of hexagon columns
-	marginSize    = 30 * scale
-	smallTextSize = 24 * scale
+	marginSize    = 30
+	smallTextSize = 24
+	// window
+	defaultWindowScale = 2 // the window opens at twice the design size
 	// points
 	clearBoardBonus  = 5_000
 	lowestPointValue = 1.0
 	increment        = 1.0
+	// time attack
+	ticksPerSecond         = ebiten.DefaultTPS
+	timeAttackSeconds      = 3 * 60
+	timeAttackBonusSeconds = 5 // per completed loop
+	lowTimeSeconds         = 10
+	// blitz
+	blitzMoveSeconds = 5 // time to place each tile before it is placed for you
+	// streak
+	maxStreakMultiplier = 5
+	streakPopTicks      = 20
+	streakPopScale      = 0.5 // how much bigger the streak text is at the start of the pop
 )
 
 const (
@@ -36,10 +53,15 @@ const (
 	tutorialScreenExplanation
 	tutorialScreen1
 	tutorialScreen2
+	gameOverScreen
+	themesScreen
+	settingsScreen
 	//hexGridWidth = hexagon.HexSideRadius * (cols + 1) // +3 in parentheses if you want to accommodate for the current hexagon on the sidebar
 	hexGridHeight = hexagon.HexVertexRadius * (rows*3 + 0.5)
 	screenWidth   = hexGridHeight + smallTextSize + marginSize*2 // int(hexGridWidth) + marginSize*2
 	screenHeight  = int(hexGridHeight) + marginSize*2 + smallTextSize*2
+	scoreTextX    = 39.5
+	scoreTextY    = marginSize/2 + marginSize
 )
 
 var (
@@ -62,109 +84,166 @@ var (
 	}
 )
 
-// TODO add drawings to image to improve performance
 // TODO make unit tests
 // TODO make clickableShape interface (arrow, hexagon, etc.)
 // TODO Play Game button then start button (don't start until cursor is up again)
-// TODO Game Over (Message that it's over and option to play again)
-// TODO Start Over (Menu)
-// TODO Landing Page (Play, Themes, How To Play)
 // TODO Smaller board with three options ?
 // TODO Puzzle Mode (set of tiles to make one loop?)
 // TODO Challenge Mode (obstacles?, hexes to clear?
-// TODO Add internal timer (https://arc.net/l/quote/vlbnnjos)
 
 type sceneType uint8
 
+type gameMode uint8
+
+const (
+	classicMode gameMode = iota
+	timeAttackMode
+	blitzMode
+)
+
 // Game represents the game state
 type Game struct {
 	hexes                     []*hexagon.Hex // List of hexagons
 	possibleConnections       [][]hexagon.Connection
 	loops                     []hexagon.Loop
 	theme                     *color2.Theme
+	themes                    []*color2.Theme // offered on the themes screen
+	themePreviews             []*ebiten.Image // a sample board drawn with each theme
+	hoveredTheme              int             // index into themes, -1 when no theme is hovered
+	themeError                error           // what was wrong with the last theme files dropped, or hard to see
+	ticks                     int             // counted from the start of the game, animates the theme
 	nextConnectionsIndex      int
 	ScreenWidth, ScreenHeight int
+	layoutScale               float64 // screen pixels per design pixel
 	disabledTicksLeft         int
 	score                     int
 	highScore                 int // TODO get highScore to save on web
+	streak                    int // consecutive placements that closed at least one loop
+	bestStreak                int
+	streakPopTicksLeft        int
+	mode                      gameMode
+	timeLeftTicks             int
+	moveTicksLeft             int
 	gameInProgress            bool
+	paused                    bool
 	currentSceneType          sceneType
 	titleHexes                []*hexagon.TextHexagon
 	titleBoardImage           *ebiten.Image
+	titleBoardStale           bool // the theme changed since the title board image was drawn
 	nextArrowHovered          bool
+	cursorActive              bool // the board is played with the cursor from the keyboard or touch, not the mouse
+	cursorRow, cursorCol      int  // grid position of the cursor
+	focus                     int  // index of the control focused with Tab, -1 when none is
+	input                     *input.State
+	readInput                 func() input.Frame // readFrame, or scripted frames in tests
+	touching                  bool               // touchID is on the board
+	touchID                   int
+	touchPlaces               bool    // lifting touchID places the tile, it was a second tap on the cursor
+	deviceScale               float64 // screen pixels per device independent pixel
+	rebinding                 int     // index of the action waiting for a control to bind on the settings screen, -1 when none is
+	rebindTaken               string  // the control last pressed for the rebinding action that another action has
+	hoveredAction             int     // index of the action under the mouse on the settings screen, -1 when none is
+	actionFocused             []bool  // the settings rows focused with Next and Previous
+	pauseButtonHovered        bool
 	startButton               *hexagon.TextHexagon
+	timeAttackButton          *hexagon.TextHexagon
+	blitzButton               *hexagon.TextHexagon
 	tutorialButton            *hexagon.TextHexagon
+	themesButton              *hexagon.TextHexagon
+	themesUploadButton        *hexagon.TextHexagon
+	themesBackButton          *hexagon.TextHexagon
+	settingsButton            *hexagon.TextHexagon
+	settingsResetButton       *hexagon.TextHexagon
+	settingsBackButton        *hexagon.TextHexagon
+	tutorialStartButton       *hexagon.TextHexagon
+	resumeButton              *hexagon.TextHexagon
+	restartButton             *hexagon.TextHexagon
+	quitButton                *hexagon.TextHexagon
+	playAgainButton           *hexagon.TextHexagon
+	menuButton                *hexagon.TextHexagon
+	animationOptions          AnimationOptions
+	placedHex                 *hexagon.Hex
+	placementTween            *animation.Tween
+	loopPulseTween            *animation.Tween
+	clearTween                *animation.Tween
+	clearingHexes             map[*hexagon.Hex]struct{} // the hexes of the loops clearTween is clearing
+	effects                   effects
+	boardCache                *draw.BoardCache
+	svgPath                   string // where SaveBoard writes the board
 }
 
 // NewGame initializes the game state
 func NewGame() *Game {
-	titleHexes, startButton, tutorialButton := newTitleHexes(screenWidth, screenHeight)
+	titleHexes, startButton, timeAttackButton, blitzButton, tutorialButton, themesButton, settingsButton := newTitleHexes()
+	resumeButton, restartButton, quitButton := newPauseMenuButtons()
+	playAgainButton, menuButton := newGameOverButtons()
+	themesUploadButton, themesBackButton := newThemesButtons()
+	settingsResetButton, settingsBackButton := newSettingsButtons()
+	themes := newThemes()
 	g := Game{
-		hexes:                newHexes(rows, cols, hexagon.HexVertexRadius, draw.HexagonStrokeWidth, draw.ConnectionWidth, getGameBoardFirstHexCoordinate()),
+		hexes:                newHexes(rows, cols, hexagon.HexVertexRadius, draw.HexagonStrokeWidth(1), draw.ConnectionWidth(1), hexagon.Coordinate{}),
 		possibleConnections:  connectionPermutations,
-		theme:                color2.NewDefaultTheme(),
+		theme:                loadTheme(themes),
+		themes:               themes,
+		hoveredTheme:         -1,
+		focus:                -1,
+		input:                input.NewState(loadBindings()),
+		readInput:            readFrame,
+		rebinding:            -1,
+		hoveredAction:        -1,
+		actionFocused:        make([]bool, len(input.Actions())),
 		nextConnectionsIndex: rand.Intn(len(connectionPermutations)),
-		ScreenWidth:          screenWidth,
-		ScreenHeight:         screenHeight,
 		gameInProgress:       true,
 		currentSceneType:     titleScreen,
 		titleHexes:           titleHexes,
 		startButton:          startButton,
+		timeAttackButton:     timeAttackButton,
+		blitzButton:          blitzButton,
 		tutorialButton:       tutorialButton,
+		themesButton:         themesButton,
+		themesUploadButton:   themesUploadButton,
+		themesBackButton:     themesBackButton,
+		settingsButton:       settingsButton,
+		settingsResetButton:  settingsResetButton,
+		settingsBackButton:   settingsBackButton,
+		tutorialStartButton:  newTutorialStartButton(),
+		resumeButton:         resumeButton,
+		restartButton:        restartButton,
+		quitButton:           quitButton,
+		playAgainButton:      playAgainButton,
+		menuButton:           menuButton,
+		animationOptions:     DefaultAnimationOptions(),
+		boardCache:           draw.NewBoardCache(),
 	}
-	g.generateTitleBoardImage(screenWidth, screenHeight)
+	g.resize(int(screenWidth*defaultWindowScale), screenHeight*defaultWindowScale)
 	return &g
 }
 
-func newTitleHexes(screenWidth, screenHeight int) (titleHexes []*hexagon.TextHexagon, startButton, tutorialButton *hexagon.TextHexagon) {
-	originX := float64(screenWidth) / 2
-	originY := float64(screenHeight)/2 - (hexagon.HexVertexRadiusTest * 2.5)
-	startButtonText := "Start"
-	tutorialButtonText := "How to Play"
-	for row := range 2 {
-		for col := -3; col < 4; col++ {
-			addConnections := false
-			str := ""
-			textSize := float64(smallTextSize)
-			if row == 0 && col == -2 {
-				str = "H"
-				textSize = float64(smallTextSize * 3)
-			} else if row == 0 && col == 0 {
-				str = "E"
-				textSize = float64(smallTextSize * 3)
-			} else if row == 0 && col == 2 {
-				str = "X"
-				textSize = float64(smallTextSize * 3)
-			} else if row == 0 && col == -3 {
-				str = "L"
-				textSize = float64(smallTextSize * 3)
-			} else if row == 0 && (col == -1 || col == 1) {
-				str = "O"
-				textSize = float64(smallTextSize * 3)
-			} else if row == 0 && col == 3 {
-				str = "P"
-				textSize = float64(smallTextSize * 3)
-			} else if row == 1 && col == -3 {
-				str = startButtonText
-			} else if row == 1 && col == 3 {
-				str = tutorialButtonText
-			} else {
-				addConnections = true
-			}
-			hex := hexagon.NewTextHexagon(col, row, originX, originY, hexagon.HexVertexRadiusTest, draw.TitleHexagonStrokeWidth, draw.TitleConnectionWidth, str, textSize)
-			if str == startButtonText {
-				startButton = hex
-			}
-			if str == tutorialButtonText {
-				tutorialButton = hex
-			}
-			if addConnections {
-				hex.Connections = connectionPermutations[rand.Intn(len(connectionPermutations))]
-			}
-			titleHexes = append(titleHexes, hex)
+func newTitleHexes() (titleHexes []*hexagon.TextHexagon, startButton, timeAttackButton, blitzButton, tutorialButton, themesButton, settingsButton *hexagon.TextHexagon) {
+	titleHexes = hexagon.NewTitleHexes(draw.TitleHexagonStrokeWidth(1), draw.TitleConnectionWidth(1), smallTextSize*3, smallTextSize)
+	for _, hex := range titleHexes {
+		switch hex.Str {
+		case hexagon.StartButton:
+			startButton = hex
+		case hexagon.TimeAttackButton:
+			timeAttackButton = hex
+		case hexagon.BlitzButton:
+			blitzButton = hex
+		case hexagon.TutorialButton:
+			tutorialButton = hex
+		case hexagon.ThemesButton:
+			themesButton = hex
+		case hexagon.SettingsButton:
+			settingsButton = hex
+		case "":
+			hex.Connections = connectionPermutations[rand.Intn(len(connectionPermutations))]
 		}
 	}
-	return titleHexes, startButton, tutorialButton
+	return titleHexes, startButton, timeAttackButton, blitzButton, tutorialButton, themesButton, settingsButton
+}
+
+func newTutorialStartButton() *hexagon.TextHexagon {
+	return newButton(0, 0, "Start", smallTextSize)
 }
 
 func newHexes(numRows, numCols int, vertexRadius float64, edgeWidth, connectionWidth float32, origin hexagon.Coordinate) (hexes []*hexagon.Hex) {
@@ -190,19 +269,47 @@ func (this *Game) Draw(screen *ebiten.Image) {
 		this.drawTutorialScreen1(screen)
 	} else if this.currentSceneType == tutorialScreen2 {
 		this.drawTutorialScreen2(screen)
+	} else if this.currentSceneType == gameOverScreen {
+		this.drawGameOverScreen(screen)
+	} else if this.currentSceneType == themesScreen {
+		this.drawThemesScreen(screen)
+	} else if this.currentSceneType == settingsScreen {
+		this.drawSettingsScreen(screen)
 	} else {
 		panic("unknown sceneType")
 	}
+	this.drawGamepadPrompt(screen)
 }
 
-// Layout sets the screen size
-func (this *Game) Layout(_, _ int) (int, int) {
-	return screenWidth, screenHeight
-	//return outsideWidth, outsideHeight
+// Layout is never called by ebiten because Game implements LayoutF
+func (this *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	width, height := this.LayoutF(float64(outsideWidth), float64(outsideHeight))
+	return int(width), int(height)
+}
+
+// LayoutF fills the window at the monitor's native resolution so text and connections stay crisp on high-DPI displays,
+// laying the game out again whenever the size changes
+func (this *Game) LayoutF(outsideWidth, outsideHeight float64) (float64, float64) {
+	deviceScale := ebiten.Monitor().DeviceScaleFactor()
+	this.deviceScale = deviceScale
+	width, height := int(math.Ceil(outsideWidth*deviceScale)), int(math.Ceil(outsideHeight*deviceScale))
+	if width > 0 && height > 0 && (width != this.ScreenWidth || height != this.ScreenHeight) {
+		this.resize(width, height)
+	}
+	return float64(this.ScreenWidth), float64(this.ScreenHeight)
 }
 
 // Update handles game logic updates
 func (this *Game) Update() error {
+	this.input.Update(this.readInput())
+	if this.input.JustPressed(input.Fullscreen) {
+		toggleFullscreen()
+	}
+	if this.input.JustPressed(input.SaveBoard) {
+		this.saveSVG()
+	}
+	this.updateTheme()
+	scene, paused := this.currentSceneType, this.paused
 	switch this.currentSceneType {
 	case gameScreen:
 		this.updateGameScreen()
@@ -214,17 +321,81 @@ func (this *Game) Update() error {
 		this.updateTutorial2Screen()
 	case titleScreen:
 		this.updateTitleScreen()
+	case gameOverScreen:
+		this.updateGameOverScreen()
+	case themesScreen:
+		this.updateThemesScreen()
+	case settingsScreen:
+		this.updateSettingsScreen()
 	default:
 		this.currentSceneType = titleScreen
 		this.updateTitleScreen()
 	}
+	if this.currentSceneType != scene || this.paused != paused {
+		this.resetFocus()
+	}
 	return nil
 }
 
+// WriteSVG writes the current board as an SVG document
+func (this *Game) WriteSVG(w io.Writer) error {
+	return svg.Write(w, this.hexes, this.loops, this.theme)
+}
+
+// SetSVGPath sets the file the board is written to when SaveBoard is pressed, nothing is written when it is empty
+func (this *Game) SetSVGPath(path string) {
+	this.svgPath = path
+}
+
+// saveSVG writes the board to svgPath for docs and bug reports
+func (this *Game) saveSVG() {
+	if this.svgPath == "" {
+		return
+	}
+	file, err := os.Create(this.svgPath)
+	if err == nil {
+		err = errors.Join(this.WriteSVG(file), file.Close())
+	}
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	log.Printf("saved the board to %s", this.svgPath)
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func (this *Game) drawScore(screen *ebiten.Image) {
-	text.Draw(screen, this.scoreString(this.score), getTextFace(smallTextSize), getDrawScoreOptions(this.theme.ConnectionColor))
+	text.Draw(screen, this.scoreString(this.score), font.Face(this.px(smallTextSize)), this.getDrawScoreOptions(this.theme.ConnectionColor))
+}
+
+func (this *Game) drawStreak(screen *ebiten.Image) {
+	if this.streak < 2 {
+		return
+	}
+	face := font.Face(this.px(smallTextSize))
+	str := streakString(this.streak)
+	width, height := text.Measure(str, face, 0)
+	x := this.px(scoreTextX) + text.Advance(this.scoreString(this.score), face) + this.px(marginSize/2)
+	pop := 1 + streakPopScale*float64(this.streakPopTicksLeft)/streakPopTicks
+	drawOptions := &text.DrawOptions{}
+	// scale around the center of the text so it pops in place
+	drawOptions.GeoM.Translate(-width/2, -height/2)
+	drawOptions.GeoM.Scale(pop, pop)
+	drawOptions.GeoM.Translate(x+width/2, this.px(scoreTextY)+height/2)
+	drawOptions.ColorScale.ScaleWithColor(this.theme.ConnectionColor)
+	text.Draw(screen, str, face, drawOptions)
+}
+
+func (this *Game) drawBestStreak(screen *ebiten.Image) {
+	if this.bestStreak < 2 {
+		return
+	}
+	drawOptions := &text.DrawOptions{}
+	drawOptions.GeoM.Translate(float64(this.ScreenWidth)-this.px(10), this.px(marginSize/2))
+	drawOptions.ColorScale.ScaleWithColor(this.theme.ConnectionColor)
+	drawOptions.PrimaryAlign = text.AlignEnd
+	text.Draw(screen, bestStreakString(this.bestStreak), font.Face(this.px(smallTextSize)), drawOptions)
 }
 
 func (this *Game) drawHexagonGameBoard(screen *ebiten.Image) {
@@ -234,6 +405,9 @@ func (this *Game) drawHexagonGameBoard(screen *ebiten.Image) {
 }
 
 func (g *Game) generateTitleBoardImage(width, height int) {
+	if g.titleBoardImage != nil {
+		g.titleBoardImage.Deallocate()
+	}
 	img := ebiten.NewImage(width, height)
 
 	for _, hex := range g.titleHexes {
@@ -244,6 +418,7 @@ func (g *Game) generateTitleBoardImage(width, height int) {
 	}
 
 	g.titleBoardImage = img
+	g.titleBoardStale = false
 }
 
 func (this *Game) drawHexagonTitleBoard(screen *ebiten.Image) {
@@ -254,10 +429,26 @@ func (this *Game) drawHexagonTitleBoard(screen *ebiten.Image) {
 	}
 }
 
+// drawCachedBoard draws the board from the board cache with animated hexes drawn on top
+func (this *Game) drawCachedBoard(screen *ebiten.Image) {
+	this.boardCache.Draw(screen, this.hexes, this.theme, this.animating)
+	if this.clearing() {
+		this.drawClearingHexagons(screen)
+	}
+	this.drawPlacementAnimation(screen)
+}
+
 func (this *Game) drawPlacedHexagons(screen *ebiten.Image) {
 	for _, hex := range this.hexes {
+		if this.animating(hex) {
+			continue
+		}
 		draw.HexagonConnections(screen, hex, this.theme.ConnectionColor, this.theme)
 	}
+	if this.clearing() {
+		this.drawClearingHexagons(screen)
+	}
+	this.drawPlacementAnimation(screen)
 }
 
 func (this *Game) drawPendingConnections(screen *ebiten.Image, hex *hexagon.Hex) {
@@ -268,12 +459,12 @@ func (this *Game) drawPendingConnections(screen *ebiten.Image, hex *hexagon.Hex)
 		for j := range nextConns[i] {
 			side := nextConns[i][j]
 			nextHex := hex
-			draw.HexagonConnection(screen, nextHex, nextConns[i], this.theme.PendingConnectionColors[i%3], this.theme.BackgroundColor)
+			draw.PatternedConnection(screen, nextHex, nextConns[i], pendingPattern(this.theme, i), this.theme.PendingConnectionColors[i%3], this.theme.BackgroundColor)
 			nextSide := side
 			drawn := true
 			k := 0
 			for {
-				nextHex, nextSide, drawn = this.drawPendingLoops(screen, nextHex, nextSide, hex, this.theme.PendingConnectionColors[i%3])
+				nextHex, nextSide, drawn = this.drawPendingLoops(screen, nextHex, nextSide, hex, this.theme.PendingConnectionColors[i%3], pendingPattern(this.theme, i))
 				if !drawn || nextHex == nil || k > rows*cols*5 {
 					break
 				}
@@ -284,17 +475,17 @@ func (this *Game) drawPendingConnections(screen *ebiten.Image, hex *hexagon.Hex)
 }
 
 func (this *Game) drawCurrentHexPattern(screen *ebiten.Image) {
-	origin := getGameBoardFirstHexCoordinate()
-	currentHex := hexagon.NewHex(cols+2, 0, origin[0], origin[1], hexagon.HexVertexRadius, draw.HexagonStrokeWidth, draw.ConnectionWidth)
+	origin := this.getGameBoardFirstHexCoordinate()
+	currentHex := hexagon.NewHex(cols+2, 0, origin[0], origin[1], this.px(hexagon.HexVertexRadius), draw.HexagonStrokeWidth(this.layoutScale), draw.ConnectionWidth(this.layoutScale))
 	draw.Hexagon(screen, currentHex, this.theme.PendingHexBorderColor)
 	this.drawPendingConnections(screen, currentHex)
 }
 
-func (this *Game) drawPendingLoops(screen *ebiten.Image, hex *hexagon.Hex, side int, hoveredHex *hexagon.Hex, color color.RGBA) (nextHex *hexagon.Hex, nextSide int, drawn bool) {
+func (this *Game) drawPendingLoops(screen *ebiten.Image, hex *hexagon.Hex, side int, hoveredHex *hexagon.Hex, color color.RGBA, pattern draw.Pattern) (nextHex *hexagon.Hex, nextSide int, drawn bool) {
 	// TODO make iterator
 	nextHexConnection := this.getNextHexConnection(hex, side, hoveredHex)
 	if nextHexConnection.Hex != nil {
-		draw.HexagonConnection(screen, nextHexConnection.Hex, nextHexConnection.Connection, color, this.theme.BackgroundColor)
+		draw.PatternedConnection(screen, nextHexConnection.Hex, nextHexConnection.Connection, pattern, color, this.theme.BackgroundColor)
 		return nextHexConnection.Hex, nextHexConnection.Connection[1], true
 	}
 	return nil, -1, false
@@ -308,21 +499,60 @@ func (this *Game) drawPendingHex(screen *ebiten.Image, hoveredHex *hexagon.Hex)
 }
 
 func (this *Game) drawCompletedLoops(screen *ebiten.Image) {
-	draw.Loops(screen, this.loops, this.theme.CompletedLoopColor, this.theme.BackgroundColor)
+	if this.clearing() {
+		this.drawClearingLoops(screen)
+		return
+	}
+	drawLoops(screen, this.loops, this.theme)
+	this.drawLoopPulse(screen)
+}
+
+// pendingPattern returns the pattern of the pending tile's i-th path, solid unless the theme uses patterns
+func pendingPattern(theme *color2.Theme, i int) draw.Pattern {
+	if !theme.Patterns {
+		return draw.SolidPattern
+	}
+	return draw.PendingPatterns[i%len(draw.PendingPatterns)]
+}
+
+// drawLoops draws completed loops, outlined when the theme uses patterns
+func drawLoops(screen *ebiten.Image, loops []hexagon.Loop, theme *color2.Theme) {
+	drawFadedLoops(screen, loops, theme, 0, 1)
+}
+
+// drawFadedLoops draws completed loops faded into the background, from 0 for not faded to 1 for gone,
+// and scaled about the centers of their hexes
+func drawFadedLoops(screen *ebiten.Image, loops []hexagon.Loop, theme *color2.Theme, fade, scale float64) {
+	loopColor := color2.Lerp(theme.CompletedLoopColor, theme.BackgroundColor, fade)
+	if theme.Patterns {
+		draw.OutlinedLoops(screen, loops, scale, loopColor, color2.Lerp(theme.ConnectionColor, theme.BackgroundColor, fade), theme.BackgroundColor)
+		return
+	}
+	draw.Loops(screen, loops, scale, loopColor, theme.BackgroundColor)
 }
 
 func (this *Game) drawHighScore(screen *ebiten.Image) {
-	text.Draw(screen, this.highScoreString(this.highScore), getTextFace(smallTextSize), getDrawHighScoreOptions(this.theme.ConnectionColor))
+	text.Draw(screen, this.highScoreString(this.highScore), font.Face(this.px(smallTextSize)), this.getDrawHighScoreOptions(this.theme.ConnectionColor))
 }
 
 func (this *Game) drawGameScreen(screen *ebiten.Image) {
 	this.drawScore(screen)
+	this.drawStreak(screen)
 	this.drawHighScore(screen)
-	this.drawHexagonGameBoard(screen)
-	this.drawPlacedHexagons(screen)
+	this.drawBestStreak(screen)
+	this.drawCachedBoard(screen)
+	this.drawShimmer(screen, this.hexes)
 	//this.drawCurrentHexPattern(screen)
 	this.drawPendingHex(screen, this.getHoveredHex())
+	this.drawCursor(screen)
 	this.drawCompletedLoops(screen)
+	this.effects.draw(screen)
+	this.drawTimer(screen)
+	this.drawMoveTimer(screen, this.getHoveredHex())
+	this.drawPauseButton(screen)
+	if this.paused {
+		this.drawPauseMenu(screen)
+	}
 }
 
 func (this *Game) drawScreenBorder(screen *ebiten.Image) {
@@ -335,61 +565,78 @@ func (this *Game) drawScreenBorder(screen *ebiten.Image) {
 
 func (this *Game) drawTitleScreen(screen *ebiten.Image) {
 	this.drawHexagonTitleBoard(screen)
+	for _, hex := range this.titleHexes {
+		this.drawShimmer(screen, []*hexagon.Hex{hex.Hex})
+	}
 	if this.startButton.Hovered {
 		draw.Hexagon(screen, this.startButton.Hex, this.theme.PendingHexBorderColor)
 	}
+	if this.timeAttackButton.Hovered {
+		draw.Hexagon(screen, this.timeAttackButton.Hex, this.theme.PendingHexBorderColor)
+	}
+	if this.blitzButton.Hovered {
+		draw.Hexagon(screen, this.blitzButton.Hex, this.theme.PendingHexBorderColor)
+	}
 	if this.tutorialButton.Hovered {
 		draw.Hexagon(screen, this.tutorialButton.Hex, this.theme.PendingHexBorderColor)
 	}
+	if this.themesButton.Hovered {
+		draw.Hexagon(screen, this.themesButton.Hex, this.theme.PendingHexBorderColor)
+	}
+	if this.settingsButton.Hovered {
+		draw.Hexagon(screen, this.settingsButton.Hex, this.theme.PendingHexBorderColor)
+	}
 }
 
 func (this *Game) drawNextArrow(screen *ebiten.Image, clr color.RGBA) {
-	startX := float32(this.ScreenWidth - 70)
-	startY := float32(marginSize + smallTextSize/2)
-	unit := float32(smallTextSize / 3)
+	startX, startY := this.nextArrowPosition()
+	unit := float32(this.px(smallTextSize / 3))
 	this.drawArrow(screen, startX, startY, unit, clr)
 }
 
 func (this *Game) drawArrow(screen *ebiten.Image, startX, startY, unit float32, clr color.RGBA) {
+	strokeWidth := draw.HexagonStrokeWidth(this.layoutScale)
 	// vertical line down
-	vector.StrokeLine(screen, startX, startY, startX, startY+(unit), draw.HexagonStrokeWidth, clr, true)
+	vector.StrokeLine(screen, startX, startY, startX, startY+(unit), strokeWidth, clr, true)
 	// bottom line
-	vector.StrokeLine(screen, startX, startY+unit, startX+(unit*4), startY+unit, draw.HexagonStrokeWidth, clr, true)
+	vector.StrokeLine(screen, startX, startY+unit, startX+(unit*4), startY+unit, strokeWidth, clr, true)
 	// then down
-	vector.StrokeLine(screen, startX+(unit*4), startY+unit, startX+(unit*4), startY+unit*2, draw.HexagonStrokeWidth, clr, true)
+	vector.StrokeLine(screen, startX+(unit*4), startY+unit, startX+(unit*4), startY+unit*2, strokeWidth, clr, true)
 	// then diagonal
-	vector.StrokeLine(screen, startX+(unit*4), startY+unit*2, startX+unit*7, startY, draw.HexagonStrokeWidth, clr, true)
+	vector.StrokeLine(screen, startX+(unit*4), startY+unit*2, startX+unit*7, startY, strokeWidth, clr, true)
 
 	// vertical line up
-	vector.StrokeLine(screen, startX, startY, startX, startY-unit, draw.HexagonStrokeWidth, clr, true)
+	vector.StrokeLine(screen, startX, startY, startX, startY-unit, strokeWidth, clr, true)
 	// top line
-	vector.StrokeLine(screen, startX, startY-unit, startX+(unit*4), startY-unit, draw.HexagonStrokeWidth, clr, true)
+	vector.StrokeLine(screen, startX, startY-unit, startX+(unit*4), startY-unit, strokeWidth, clr, true)
 	// then up
-	vector.StrokeLine(screen, startX+(unit*4), startY-unit, startX+(unit*4), startY-unit*2, draw.HexagonStrokeWidth, clr, true)
+	vector.StrokeLine(screen, startX+(unit*4), startY-unit, startX+(unit*4), startY-unit*2, strokeWidth, clr, true)
 	// then diagonal
-	vector.StrokeLine(screen, startX+(unit*4), startY-unit*2, startX+unit*7, startY, draw.HexagonStrokeWidth, clr, true)
+	vector.StrokeLine(screen, startX+(unit*4), startY-unit*2, startX+unit*7, startY, strokeWidth, clr, true)
 
 }
 
 func (this *Game) drawTutorialText(screen *ebiten.Image, content string) {
 	drawOptions := &text.DrawOptions{}
-	drawOptions.GeoM.Translate(20, (marginSize+smallTextSize)/2)
+	drawOptions.GeoM.Translate(this.px(10), this.px((marginSize+smallTextSize)/2))
 	drawOptions.ColorScale.ScaleWithColor(this.theme.ConnectionColor)
-	text.Draw(screen, content, getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, content, font.Face(this.px(smallTextSize)), drawOptions)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func (this *Game) updateGameScreen() {
+	tapped := this.updatePauseButton()
+	if this.input.JustPressed(input.Pause) || (this.input.JustPressed(input.Click) && this.pauseButtonHovered) || tapped {
+		this.paused = !this.paused
+		return
+	}
+	if this.paused {
+		this.updatePauseMenu()
+		return
+	}
 	if this.gameInProgress {
 		this.updateGameInProgress()
-	} else {
-		if this.disabledTicksLeft > 0 {
-			this.disabledTicksLeft--
-		} else if this.disabledTicksLeft == 0 {
-			this.startOver()
-			this.gameInProgress = true
-		}
 	}
 }
 
@@ -397,19 +644,61 @@ func (this *Game) updateScore(points int) {
 	this.score += points
 }
 
+// updateStreak extends the streak if the last placement closed a loop and resets it otherwise
+func (this *Game) updateStreak(closedLoop bool) {
+	if !closedLoop {
+		this.streak = 0
+		return
+	}
+	this.streak++
+	this.bestStreak = max(this.bestStreak, this.streak)
+	this.streakPopTicksLeft = streakPopTicks
+}
+
 func (this *Game) updateClickedHex(mouseX, mouseY int) {
 	for _, hex := range this.hexes {
 		if hex.PointInHexagon(float64(mouseX), float64(mouseY)) && hex.Empty() {
-			hex.Connections = this.nextConnections()
-			this.updateNextConnections()
-			this.loops = this.getCompleteLoops(hex)
-			if len(this.loops) > 0 {
-				this.disabledTicksLeft = 50
-			}
+			this.placeTile(hex)
+			return
 		}
 	}
 }
 
+// placeTile places the next tile on the hex and scores the loops it completes
+func (this *Game) placeTile(hex *hexagon.Hex) {
+	hex.Connections = this.nextConnections()
+	this.updateNextConnections()
+	this.loops = this.getCompleteLoops(hex)
+	this.startPlacementAnimation(hex)
+	if len(this.loops) > 0 {
+		this.disabledTicksLeft = this.startLoopAnimation()
+	}
+	this.updateStreak(len(this.loops) > 0)
+	this.updateScore(calculatePoints(this.loops) * streakMultiplier(this.streak))
+	this.addLoopEffects(this.loops, streakMultiplier(this.streak))
+	if this.mode == timeAttackMode {
+		this.timeLeftTicks += len(this.loops) * timeAttackBonusSeconds * ticksPerSecond
+	}
+	this.moveTicksLeft = blitzMoveSeconds * ticksPerSecond
+}
+
+// updateMoveTimer places the tile on a random empty hex when a blitz move runs out of time
+func (this *Game) updateMoveTimer() {
+	if this.mode != blitzMode {
+		return
+	}
+	this.moveTicksLeft--
+	if this.moveTicksLeft > 0 {
+		return
+	}
+	if hex := this.getRandomEmptyHex(); hex != nil {
+		this.placeTile(hex)
+		return
+	}
+	// the board is full while its loops are cleared, the next move gets the whole time
+	this.moveTicksLeft = blitzMoveSeconds * ticksPerSecond
+}
+
 func (this *Game) updateNextConnections() {
 	this.nextConnectionsIndex = rand.Intn(len(this.possibleConnections))
 }
@@ -428,85 +717,112 @@ func (this *Game) updateHoveredHex(mouseX, mouseY int) {
 
 func (this *Game) updateGameInProgress() {
 	if this.gameOver() {
-		this.gameInProgress = false
-		this.highScore = max(this.highScore, this.score)
+		this.endGame()
+		return
+	}
+	if this.mode == timeAttackMode && this.timeLeftTicks > 0 {
+		this.timeLeftTicks--
+	}
+	if this.streakPopTicksLeft > 0 {
+		this.streakPopTicksLeft--
 	}
+	this.updateAnimations()
+	this.effects.update()
 	if this.disabledTicksLeft > 0 {
 		this.disabledTicksLeft--
 		if this.disabledTicksLeft == 0 {
 			newGame := this.boardEmpty()
 			this.removeCompletedLoops()
+			this.resetAnimations()
 			if this.boardEmpty() && !newGame {
 				this.updateScore(clearBoardBonus)
+				this.addClearBoardEffects()
 			}
 		}
 		return
 	}
 
-	mouseX, mouseY := ebiten.CursorPosition()
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	this.updateMoveTimer()
+	if this.disabledTicksLeft > 0 {
+		return
+	}
+	this.updateTouchCursor()
+	if this.updateCursor() {
+		return
+	}
+
+	mouseX, mouseY := this.input.Mouse()
+	if this.input.JustPressed(input.Click) {
 		this.updateClickedHex(mouseX, mouseY)
-		this.updateScore(calculatePoints(this.loops))
 	} else {
 		this.updateHoveredHex(mouseX, mouseY)
 	}
 }
 
 func (this *Game) updateTitleScreen() {
-	mouseX, mouseY := ebiten.CursorPosition()
-	if this.startButton.PointInHexagon(float64(mouseX), float64(mouseY)) {
-		this.startButton.Hovered = true
-	} else {
-		this.startButton.Hovered = false
-	}
-	if this.tutorialButton.PointInHexagon(float64(mouseX), float64(mouseY)) {
-		this.tutorialButton.Hovered = true
-	} else {
-		this.tutorialButton.Hovered = false
+	if this.titleBoardStale {
+		this.generateTitleBoardImage(this.ScreenWidth, this.ScreenHeight)
 	}
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	mouseX, mouseY := this.input.Mouse()
+	updateButtonHovered(this.startButton, mouseX, mouseY)
+	updateButtonHovered(this.timeAttackButton, mouseX, mouseY)
+	updateButtonHovered(this.blitzButton, mouseX, mouseY)
+	updateButtonHovered(this.tutorialButton, mouseX, mouseY)
+	updateButtonHovered(this.themesButton, mouseX, mouseY)
+	updateButtonHovered(this.settingsButton, mouseX, mouseY)
+	pressed := this.updateFocus(&this.startButton.Hovered, &this.timeAttackButton.Hovered, &this.themesButton.Hovered, &this.blitzButton.Hovered, &this.settingsButton.Hovered, &this.tutorialButton.Hovered)
+	tapped := this.updateButtonsTapped(this.startButton, this.timeAttackButton, this.themesButton, this.blitzButton, this.settingsButton, this.tutorialButton)
+	if this.input.JustPressed(input.Click) || pressed || tapped {
 		if this.startButton.Hovered {
-			this.currentSceneType = gameScreen
+			this.startGame(classicMode)
+		}
+		if this.timeAttackButton.Hovered {
+			this.startGame(timeAttackMode)
+		}
+		if this.blitzButton.Hovered {
+			this.startGame(blitzMode)
 		}
 		if this.tutorialButton.Hovered {
 			this.currentSceneType = tutorialScreenExplanation
-			//todo initialize scene functions
-			originX := float64(this.ScreenWidth) / 2
-			originY := float64(this.ScreenHeight) - (hexagon.HexVertexRadiusTest * 1.5)
-			this.startButton = hexagon.NewTextHexagon(0, 0, originX, originY, hexagon.HexVertexRadiusTest, draw.TitleHexagonStrokeWidth, draw.TitleConnectionWidth, "Start", smallTextSize)
+		}
+		if this.themesButton.Hovered {
+			this.currentSceneType = themesScreen
+		}
+		if this.settingsButton.Hovered {
+			this.currentSceneType = settingsScreen
 		}
 	}
 }
 
-func (this *Game) updateNextArrow() {
-	mouseX, mouseY := ebiten.CursorPosition()
-	startX := float32(this.ScreenWidth - 70)
-	startY := float32(marginSize + smallTextSize/2)
-	if float32(mouseX) > startX &&
-		float32(mouseX) < startX+(smallTextSize*7/3) &&
-		float32(mouseY) < startY+smallTextSize &&
-		float32(mouseY) > startY-smallTextSize {
-		this.nextArrowHovered = true
-	} else {
-		this.nextArrowHovered = false
+// updateNextArrow hovers the next arrow under the mouse or a tap, reporting whether it was tapped
+func (this *Game) updateNextArrow() (tapped bool) {
+	startX, startY := this.nextArrowPosition()
+	textSize := float32(this.px(smallTextSize))
+	contains := func(x, y int, padding float32) bool {
+		return rectContains(x, y, startX, startY-textSize, textSize*7/3, textSize*2, padding)
 	}
+	mouseX, mouseY := this.input.Mouse()
+	this.nextArrowHovered = contains(mouseX, mouseY, 0)
+	if x, y, ok := this.justTapped(); ok {
+		this.nextArrowHovered = contains(x, y, float32(this.touchTargetRadius()))
+		return this.nextArrowHovered
+	}
+	return false
 }
 
 func (this *Game) updateTutorialExplanationScreen() {
-	mouseX, mouseY := ebiten.CursorPosition()
-	if this.startButton.PointInHexagon(float64(mouseX), float64(mouseY)) {
-		this.startButton.Hovered = true
-	} else {
-		this.startButton.Hovered = false
-	}
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && this.startButton.Hovered {
-		this.currentSceneType = gameScreen
+	mouseX, mouseY := this.input.Mouse()
+	updateButtonHovered(this.tutorialStartButton, mouseX, mouseY)
+	pressed := this.updateFocus(&this.tutorialStartButton.Hovered)
+	tapped := this.updateButtonsTapped(this.tutorialStartButton)
+	if (this.input.JustPressed(input.Click) || pressed || tapped) && this.tutorialStartButton.Hovered {
+		this.startGame(classicMode)
 	}
 }
 
 func (this *Game) updateTutorial1Screen() {
 	var checkHex *hexagon.Hex
-	hexes := newHexes(rows, cols, hexagon.HexVertexRadius, draw.HexagonStrokeWidth, draw.ConnectionWidth, getGameBoardFirstHexCoordinate())
+	hexes := this.newBoardHexes()
 	for _, hex := range hexes {
 		if hex.Row == 2 && hex.Col == 5 {
 			hex.Connections = []hexagon.Connection{{0, 5}, {1, 3}, {2, 4}}
@@ -549,8 +865,9 @@ func (this *Game) updateTutorial1Screen() {
 	}
 	this.loops = this.getCompleteLoops(checkHex)
 	this.hexes = hexes
-	this.updateNextArrow()
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && this.nextArrowHovered {
+	tapped := this.updateNextArrow()
+	pressed := this.updateFocus(&this.nextArrowHovered)
+	if (this.input.JustPressed(input.Click) || pressed || tapped) && this.nextArrowHovered {
 		this.currentSceneType = tutorialScreen2
 	}
 }
@@ -558,7 +875,7 @@ func (this *Game) updateTutorial1Screen() {
 // todo add numbers
 func (this *Game) updateTutorial2Screen() {
 	var checkHex *hexagon.Hex
-	hexes := newHexes(rows, cols, hexagon.HexVertexRadius, draw.HexagonStrokeWidth, draw.ConnectionWidth, getGameBoardFirstHexCoordinate())
+	hexes := this.newBoardHexes()
 	for _, hex := range hexes {
 		if hex.Row == 2 && hex.Col == 5 {
 			hex.Connections = []hexagon.Connection{{0, 5}, {1, 3}, {2, 4}}
@@ -601,8 +918,9 @@ func (this *Game) updateTutorial2Screen() {
 	}
 	this.loops = this.getCompleteLoops(checkHex)
 	this.hexes = hexes
-	this.updateNextArrow()
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && this.nextArrowHovered {
+	tapped := this.updateNextArrow()
+	pressed := this.updateFocus(&this.nextArrowHovered)
+	if (this.input.JustPressed(input.Click) || pressed || tapped) && this.nextArrowHovered {
 		// TODO update tutorial screen
 		this.currentSceneType = tutorialScreen2
 	}
@@ -621,6 +939,19 @@ func (this *Game) getHoveredHex() *hexagon.Hex {
 	return nil
 }
 
+func (this *Game) getRandomEmptyHex() *hexagon.Hex {
+	var emptyHexes []*hexagon.Hex
+	for _, hex := range this.hexes {
+		if hex.Empty() {
+			emptyHexes = append(emptyHexes, hex)
+		}
+	}
+	if len(emptyHexes) == 0 {
+		return nil
+	}
+	return emptyHexes[rand.Intn(len(emptyHexes))]
+}
+
 func (this *Game) getBorderHex(row, col, side int) *hexagon.Hex {
 	r, c := getBorderHexGridPosition(row, col, side)
 	return this.getHexFromGridPosition(r, c)
@@ -782,6 +1113,9 @@ func (this *Game) getNextHexConnection(hex *hexagon.Hex, connectedSide int, hove
 }
 
 func (this *Game) gameOver() bool {
+	if this.mode == timeAttackMode && this.timeLeftTicks == 0 {
+		return true
+	}
 	for _, hex := range this.hexes {
 		if hex.Empty() {
 			return false
@@ -794,48 +1128,66 @@ func (this *Game) startOver() {
 	for _, hex := range this.hexes {
 		hex.Reset()
 	}
+	// every hex changed, one full redraw is cheaper than redrawing them one by one
+	this.boardCache.Invalidate()
 	this.score = 0
+	this.streak = 0
+	this.bestStreak = 0
+	this.streakPopTicksLeft = 0
+	this.disabledTicksLeft = 0
+	this.timeLeftTicks = timeAttackSeconds * ticksPerSecond
+	this.moveTicksLeft = blitzMoveSeconds * ticksPerSecond
 	this.loops = nil
+	this.resetAnimations()
+	this.effects.clear()
+}
+
+func (this *Game) startGame(mode gameMode) {
+	this.mode = mode
+	this.startOver()
+	this.gameInProgress = true
+	this.paused = false
+	this.currentSceneType = gameScreen
+}
+
+func (this *Game) endGame() {
+	this.gameInProgress = false
+	this.highScore = max(this.highScore, this.score)
+	this.currentSceneType = gameOverScreen
 }
 
 func (this *Game) drawTutorialScreenExplanation(screen *ebiten.Image) {
-	var clr color.RGBA
-	if this.startButton.Hovered {
-		clr = this.theme.PendingHexBorderColor
-	} else {
-		clr = this.theme.HexBorderColor
-	}
-	draw.TextHexagon(screen, this.startButton, clr, this.theme.ConnectionColor)
+	this.drawButton(screen, this.tutorialStartButton)
 
 	drawOptions := &text.DrawOptions{}
 	drawOptions.ColorScale.ScaleWithColor(this.theme.ConnectionColor)
-	yTranslate := float64(marginSize+smallTextSize) / 2
-	drawOptions.GeoM.Translate(20, yTranslate)
-	text.Draw(screen, "The object of Hexloop is to place tiles to", getTextFace(smallTextSize), drawOptions)
+	yTranslate := this.px((marginSize + smallTextSize) / 2)
+	drawOptions.GeoM.Translate(this.px(10), yTranslate)
+	text.Draw(screen, "The object of Hexloop is to place tiles to", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "connect loops. The tiles used to make a loop", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "connect loops. The tiles used to make a loop", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "will disappear to give you room to place more", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "will disappear to give you room to place more", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "tiles. You will also gets points for each", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "tiles. You will also gets points for each", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "connection in the loop. The longer the loop,", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "connection in the loop. The longer the loop,", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "the more points you'll get for each segment.", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "the more points you'll get for each segment.", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "For example, a loop with 4 connections is", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "For example, a loop with 4 connections is", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "worth 10 points, but a loop with 8", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "worth 10 points, but a loop with 8", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "connections is worth 36 points! If you make", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "connections is worth 36 points! If you make", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "two loops at once, you'll get double the", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "two loops at once, you'll get double the", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "points for those loops. If you're able to get", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "points for those loops. If you're able to get", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "every placed tile cleared, you'll get a 5,000", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "every placed tile cleared, you'll get a 5,000", font.Face(this.px(smallTextSize)), drawOptions)
 	drawOptions.GeoM.Translate(0, yTranslate)
-	text.Draw(screen, "point bonus! How many points can you get?", getTextFace(smallTextSize), drawOptions)
+	text.Draw(screen, "point bonus! How many points can you get?", font.Face(this.px(smallTextSize)), drawOptions)
 
 }
 
@@ -877,6 +1229,19 @@ func (this *Game) scoreString(score int) string {
 	return "Score: " + withCommas(scoreStr)
 }
 
+func streakString(streak int) string {
+	return "Streak " + strconv.Itoa(streak) + " (x" + strconv.Itoa(streakMultiplier(streak)) + ")"
+}
+
+func bestStreakString(streak int) string {
+	return "Best Streak: " + strconv.Itoa(streak)
+}
+
+// streakMultiplier returns the multiplier applied to points earned during a streak
+func streakMultiplier(streak int) int {
+	return min(max(streak, 1), maxStreakMultiplier)
+}
+
 func calculatePoints(loops []hexagon.Loop) int {
 	connectionPoints := 0
 	for _, loop := range loops {
@@ -926,34 +1291,16 @@ func getBorderHexGridPosition(row, col, side int) (r, c int) {
 	}
 }
 
-func getGameBoardFirstHexCoordinate() hexagon.Coordinate {
-	xBuffer := marginSize + hexagon.HexSideRadius
-	yBuffer := float64(marginSize + hexagon.HexVertexRadius + (smallTextSize * 2))
-	return [2]float64{xBuffer, yBuffer}
-}
-
-func getTextFace(textSize float64) text.Face {
-	fontFaceSource, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
-	if err != nil {
-		log.Fatal(err)
-	}
-	textFace := &text.GoTextFace{
-		Source: fontFaceSource,
-		Size:   textSize,
-	}
-	return textFace
-}
-
-func getDrawScoreOptions(clr color.RGBA) *text.DrawOptions {
+func (this *Game) getDrawScoreOptions(clr color.RGBA) *text.DrawOptions {
 	drawOptions := &text.DrawOptions{}
-	drawOptions.GeoM.Translate(79, marginSize/2+marginSize)
+	drawOptions.GeoM.Translate(this.px(scoreTextX), this.px(scoreTextY))
 	drawOptions.ColorScale.ScaleWithColor(clr)
 	return drawOptions
 }
 
-func getDrawHighScoreOptions(clr color.RGBA) *text.DrawOptions {
+func (this *Game) getDrawHighScoreOptions(clr color.RGBA) *text.DrawOptions {
 	drawOptions := &text.DrawOptions{}
-	drawOptions.GeoM.Translate(20, marginSize/2)
+	drawOptions.GeoM.Translate(this.px(10), this.px(marginSize/2))
 	drawOptions.ColorScale.ScaleWithColor(clr)
 	return drawOptions
 }
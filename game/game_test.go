@@ -1,6 +1,13 @@
 package game
 
-import "testing"
+import (
+	"slices"
+	"testing"
+
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/hexagon"
+	"github.com/tliddle1/hexloop/input"
+)
 
 func Test_loopPoints(t *testing.T) {
 	type args struct {
@@ -96,3 +103,352 @@ func Test_withComma(t *testing.T) {
 		})
 	}
 }
+
+func Test_streakMultiplier(t *testing.T) {
+	type args struct {
+		streak int
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{name: "no streak", args: args{streak: 0}, want: 1},
+		{name: "first loop", args: args{streak: 1}, want: 1},
+		{name: "second loop", args: args{streak: 2}, want: 2},
+		{name: "fourth loop", args: args{streak: 4}, want: 4},
+		{name: "capped", args: args{streak: maxStreakMultiplier}, want: maxStreakMultiplier},
+		{name: "past cap", args: args{streak: maxStreakMultiplier + 3}, want: maxStreakMultiplier},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := streakMultiplier(tt.args.streak); got != tt.want {
+				t.Errorf("streakMultiplier() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_timeString(t *testing.T) {
+	type args struct {
+		ticks int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{name: "out of time", args: args{ticks: 0}, want: "0:00"},
+		{name: "partial second rounds up", args: args{ticks: 1}, want: "0:01"},
+		{name: "one second", args: args{ticks: ticksPerSecond}, want: "0:01"},
+		{name: "ten seconds", args: args{ticks: 10 * ticksPerSecond}, want: "0:10"},
+		{name: "one minute", args: args{ticks: 60 * ticksPerSecond}, want: "1:00"},
+		{name: "full time attack", args: args{ticks: timeAttackSeconds * ticksPerSecond}, want: "3:00"},
+		{name: "bonus time", args: args{ticks: (timeAttackSeconds + 65) * ticksPerSecond}, want: "4:05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timeString(tt.args.ticks); got != tt.want {
+				t.Errorf("timeString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loopCentroid(t *testing.T) {
+	hexA := hexagon.NewHex(0, 0, 0, 0, hexagon.HexVertexRadius, 1, 1)
+	hexB := hexagon.NewHex(2, 0, 0, 0, hexagon.HexVertexRadius, 1, 1)
+	loop := hexagon.Loop{
+		{Hex: hexA, Connection: hexagon.Connection{0, 1}},
+		{Hex: hexB, Connection: hexagon.Connection{4, 5}},
+	}
+	x, y := loopCentroid(loop)
+	if x != hexagon.HexSideRadius || y != 0 {
+		t.Errorf("loopCentroid() = (%v, %v), want (%v, 0)", x, y, hexagon.HexSideRadius)
+	}
+}
+
+func Test_newThemePreviewHexes(t *testing.T) {
+	hexes, loop, pending := newThemePreviewHexes(hexagon.HexVertexRadius, 2, 3)
+	game := &Game{hexes: hexes}
+	loops := game.getCompleteLoops(loop[0].Hex)
+	if len(loops) != 1 || len(loops[0]) != len(loop) {
+		t.Errorf("getCompleteLoops() = %v, want the preview's loop of %d connections", loops, len(loop))
+	}
+	if pending == nil || !pending.Empty() {
+		t.Errorf("pending hex = %v, want an empty hex", pending)
+	}
+}
+
+func Test_withTheme(t *testing.T) {
+	mint := &color2.Theme{Name: "Mint"}
+	newMint := &color2.Theme{Name: "Mint"}
+	var builtIn []string
+	for _, theme := range color2.Themes() {
+		builtIn = append(builtIn, theme.Name)
+	}
+	tests := []struct {
+		name    string
+		themes  []*color2.Theme
+		theme   *color2.Theme
+		want    []string
+		wantNew bool // the last theme is the added one
+		wantErr bool
+	}{
+		{name: "added", themes: color2.Themes(), theme: mint, want: append(slices.Clone(builtIn), "Mint"), wantNew: true},
+		{name: "replaced", themes: append(color2.Themes(), mint), theme: newMint, want: append(slices.Clone(builtIn), "Mint"), wantNew: true},
+		{name: "built-in name", themes: color2.Themes(), theme: &color2.Theme{Name: "Bee"}, want: builtIn, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := withTheme(tt.themes, tt.theme)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("withTheme() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, theme := range got {
+				names = append(names, theme.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("withTheme() = %v, want %v", names, tt.want)
+			}
+			if tt.wantNew && got[len(got)-1] != tt.theme {
+				t.Errorf("withTheme() didn't add the new theme")
+			}
+		})
+	}
+}
+
+func Test_nextFocus(t *testing.T) {
+	type args struct {
+		focus, step, n int
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{name: "tab with nothing focused", args: args{focus: -1, step: 1, n: 3}, want: 0},
+		{name: "shift tab with nothing focused", args: args{focus: -1, step: -1, n: 3}, want: 2},
+		{name: "tab", args: args{focus: 0, step: 1, n: 3}, want: 1},
+		{name: "tab wraps", args: args{focus: 2, step: 1, n: 3}, want: 0},
+		{name: "shift tab wraps", args: args{focus: 0, step: -1, n: 3}, want: 2},
+		{name: "focus left over from more controls", args: args{focus: 4, step: 1, n: 2}, want: 0},
+		{name: "no controls", args: args{focus: -1, step: 1, n: 0}, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextFocus(tt.args.focus, tt.args.step, tt.args.n); got != tt.want {
+				t.Errorf("nextFocus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_rectContains(t *testing.T) {
+	type args struct {
+		x, y    int
+		padding float32
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{name: "inside", args: args{x: 15, y: 15}, want: true},
+		{name: "outside", args: args{x: 25, y: 15}, want: false},
+		{name: "on the edge", args: args{x: 20, y: 15}, want: false},
+		{name: "inside the padding", args: args{x: 24, y: 15, padding: 10}, want: true},
+		{name: "outside the padding", args: args{x: 25, y: 15, padding: 10}, want: false},
+		{name: "padding smaller than the rect", args: args{x: 19, y: 11, padding: 1}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a 10 by 10 rect centered on (15, 15)
+			if got := rectContains(tt.args.x, tt.args.y, 10, 10, 10, 10, tt.args.padding); got != tt.want {
+				t.Errorf("rectContains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_updateFocus(t *testing.T) {
+	tab := input.Frame{Held: map[string]int{"Tab": 1}}
+	shiftTab := input.Frame{Held: map[string]int{"Tab": 1, "Shift": 1, "ShiftLeft": 1, "Shift+Tab": 1}}
+	tests := []struct {
+		name        string
+		frames      []input.Frame
+		wantFocus   int
+		wantPressed bool
+	}{
+		{name: "nothing pressed", frames: []input.Frame{{}}, wantFocus: -1},
+		{name: "tab", frames: []input.Frame{tab}, wantFocus: 0},
+		{name: "tab held", frames: []input.Frame{tab, {Held: map[string]int{"Tab": 2}}}, wantFocus: 0},
+		{name: "tab twice", frames: []input.Frame{tab, {}, tab}, wantFocus: 1},
+		{name: "shift tab", frames: []input.Frame{shiftTab}, wantFocus: 2},
+		{name: "d-pad", frames: []input.Frame{{Held: map[string]int{"GamepadDown": 1}}}, wantFocus: 0},
+		{name: "enter", frames: []input.Frame{tab, {Held: map[string]int{"Enter": 1}}}, wantFocus: 0, wantPressed: true},
+		{name: "enter with nothing focused", frames: []input.Frame{{Held: map[string]int{"Enter": 1}}}, wantFocus: -1},
+		{name: "mouse moved", frames: []input.Frame{tab, {MouseX: 10}}, wantFocus: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := &Game{input: input.NewState(input.DefaultBindings()), focus: -1}
+			hovered := make([]bool, 3)
+			var pressed bool
+			for _, frame := range tt.frames {
+				game.input.Update(frame)
+				pressed = game.updateFocus(&hovered[0], &hovered[1], &hovered[2])
+			}
+			if game.focus != tt.wantFocus || pressed != tt.wantPressed {
+				t.Errorf("updateFocus() focus = %v, pressed = %v, want %v, %v", game.focus, pressed, tt.wantFocus, tt.wantPressed)
+			}
+		})
+	}
+}
+
+func TestGame_Update(t *testing.T) {
+	click := func(x, y float64) []input.Frame {
+		mouse := input.Frame{MouseX: int(x), MouseY: int(y)}
+		return []input.Frame{mouse, {Held: map[string]int{input.MouseLeft: 1}, MouseX: mouse.MouseX, MouseY: mouse.MouseY}, mouse}
+	}
+	tap := func(x, y float64) []input.Frame {
+		return []input.Frame{{Touches: []input.Touch{{ID: 1, X: int(x), Y: int(y), Ticks: 1}}}, {}}
+	}
+	press := func(control string) []input.Frame {
+		return []input.Frame{{Held: map[string]int{control: 1}}, {}}
+	}
+	// each step returns its frames once the steps before it have been played, so it can aim at where things are then
+	type step func(game *Game) []input.Frame
+	clickStart := func(game *Game) []input.Frame { return click(game.startButton.Center[0], game.startButton.Center[1]) }
+	clickHex := func(game *Game) []input.Frame { return click(game.hexes[0].Center[0], game.hexes[0].Center[1]) }
+	tapStart := func(game *Game) []input.Frame { return tap(game.startButton.Center[0], game.startButton.Center[1]) }
+	tapHex := func(game *Game) []input.Frame { return tap(game.hexes[0].Center[0], game.hexes[0].Center[1]) }
+	pressing := func(control string) step {
+		return func(*Game) []input.Frame { return press(control) }
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{name: "mouse", steps: []step{clickStart, clickHex}},
+		{name: "touch, the first tap on a hex only moves the cursor there", steps: []step{tapStart, tapHex, tapHex}},
+		{name: "keyboard, the first arrow only shows the cursor", steps: []step{pressing("Tab"), pressing("Enter"), pressing("ArrowRight"), pressing("Enter")}},
+		{name: "gamepad", steps: []step{pressing("GamepadDown"), pressing("GamepadA"), pressing("GamepadRight"), pressing("GamepadA")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame()
+			// not the bindings saved on this machine
+			game.input = input.NewState(input.DefaultBindings())
+			var frames []input.Frame
+			game.readInput = func() input.Frame {
+				frame := frames[0]
+				frames = frames[1:]
+				return frame
+			}
+			for _, step := range tt.steps {
+				frames = step(game)
+				for len(frames) > 0 {
+					if err := game.Update(); err != nil {
+						t.Fatalf("Update() error = %v", err)
+					}
+				}
+			}
+			if game.currentSceneType != gameScreen {
+				t.Fatalf("currentSceneType = %v, want the game screen", game.currentSceneType)
+			}
+			placed := 0
+			for _, hex := range game.hexes {
+				if !hex.Empty() {
+					placed++
+				}
+			}
+			if placed != 1 {
+				t.Errorf("%d tiles placed, want 1", placed)
+			}
+		})
+	}
+}
+
+func TestGame_startLoopAnimation(t *testing.T) {
+	tests := []struct {
+		name    string
+		options AnimationOptions
+	}{
+		{name: "default", options: DefaultAnimationOptions()},
+		{name: "zero durations", options: AnimationOptions{Enabled: true}},
+		{name: "disabled", options: AnimationOptions{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame()
+			game.input = input.NewState(input.DefaultBindings())
+			game.readInput = func() input.Frame { return input.Frame{} }
+			game.SetAnimationOptions(tt.options)
+			game.startGame(classicMode)
+			hex := game.hexes[0]
+			hex.Connections = connectionPermutations[0]
+			game.loops = []hexagon.Loop{{{Hex: hex, Connection: hex.Connections[0]}}}
+			game.disabledTicksLeft = game.startLoopAnimation()
+			if game.disabledTicksLeft < 1 {
+				t.Fatalf("startLoopAnimation() = %v, want at least 1", game.disabledTicksLeft)
+			}
+			if _, ok := game.clearingHexes[hex]; tt.options.Enabled && !ok {
+				t.Errorf("clearingHexes = %v, want the loop's hex", game.clearingHexes)
+			}
+			for range game.disabledTicksLeft {
+				if err := game.Update(); err != nil {
+					t.Fatalf("Update() error = %v", err)
+				}
+			}
+			if game.loops != nil || !hex.Empty() || game.clearingHexes != nil {
+				t.Errorf("loops = %v, hex connections = %v, want the loop removed", game.loops, hex.Connections)
+			}
+		})
+	}
+}
+
+func TestGame_updateMoveTimer(t *testing.T) {
+	const moveTicks = blitzMoveSeconds * ticksPerSecond
+	tests := []struct {
+		name          string
+		mode          gameMode
+		empty         []int // the hexes left empty, the rest of the board is filled
+		moveTicksLeft int
+		wantPlaced    int
+		wantTicksLeft int
+	}{
+		{name: "time left", mode: blitzMode, empty: []int{0, 1, 2}, moveTicksLeft: 5, wantTicksLeft: 4},
+		{name: "time runs out", mode: blitzMode, empty: []int{0, 1, 2}, moveTicksLeft: 1, wantPlaced: 1, wantTicksLeft: moveTicks},
+		{name: "time runs out with one empty hex", mode: blitzMode, empty: []int{7}, moveTicksLeft: 1, wantPlaced: 1, wantTicksLeft: moveTicks},
+		{name: "time runs out with no empty hex", mode: blitzMode, moveTicksLeft: 1, wantTicksLeft: moveTicks},
+		{name: "not blitz", mode: classicMode, empty: []int{0, 1, 2}, moveTicksLeft: 1, wantTicksLeft: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame()
+			game.input = input.NewState(input.DefaultBindings())
+			game.startGame(tt.mode)
+			for i, hex := range game.hexes {
+				if !slices.Contains(tt.empty, i) {
+					hex.Connections = connectionPermutations[0]
+				}
+			}
+			game.moveTicksLeft = tt.moveTicksLeft
+			game.updateMoveTimer()
+			placed := 0
+			for _, i := range tt.empty {
+				if !game.hexes[i].Empty() {
+					placed++
+				}
+			}
+			if placed != tt.wantPlaced {
+				t.Errorf("%d tiles placed on empty hexes, want %d", placed, tt.wantPlaced)
+			}
+			if game.moveTicksLeft != tt.wantTicksLeft {
+				t.Errorf("moveTicksLeft = %v, want %v", game.moveTicksLeft, tt.wantTicksLeft)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/tliddle1/hexloop/input"
+)
+
+const gamepadPromptTextSize = smallTextSize * 2 / 3
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// drawGamepadPrompt shows what the gamepad buttons do on this screen, once the gamepad is being played with
+func (this *Game) drawGamepadPrompt(screen *ebiten.Image) {
+	if !this.input.GamepadInUse() {
+		return
+	}
+	this.drawCenteredText(screen, this.gamepadPrompt(), this.px(gamepadPromptTextSize), float64(this.ScreenHeight)-this.px(gamepadPromptTextSize*3/2))
+}
+
+func (this *Game) gamepadPrompt() string {
+	switch {
+	case this.currentSceneType == gameScreen && this.paused:
+		return fmt.Sprintf("%s  choose     %s  select     %s  resume", this.buttonFor(input.Next), this.buttonFor(input.Place), this.buttonFor(input.Pause))
+	case this.currentSceneType == gameScreen && this.gameInProgress:
+		return fmt.Sprintf("%s / %s  move     %s  place     %s  pause", this.buttonFor(input.HexRight), this.buttonFor(input.CursorRight), this.buttonFor(input.Place), this.buttonFor(input.Pause))
+	case this.currentSceneType == themesScreen || this.currentSceneType == settingsScreen:
+		return fmt.Sprintf("%s  choose     %s  select     %s  back", this.buttonFor(input.Next), this.buttonFor(input.Place), this.buttonFor(input.Back))
+	default:
+		return fmt.Sprintf("%s  choose     %s  select", this.buttonFor(input.Next), this.buttonFor(input.Place))
+	}
+}
+
+// buttonFor names the first gamepad control bound to the action for the prompt
+func (this *Game) buttonFor(action input.Action) string {
+	controls := this.input.Bindings.Gamepad(action)
+	if len(controls) == 0 {
+		return "-"
+	}
+	return gamepadLabel(controls[0])
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// gamepadLabel names the stick and the D-pad without the direction they are pushed, the prompt says what they do
+func gamepadLabel(control string) string {
+	label := input.Label(control)
+	if strings.HasPrefix(label, "Stick") || strings.HasPrefix(label, "D-pad") {
+		return strings.Fields(label)[0]
+	}
+	return label
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/tliddle1/hexloop/input"
+)
+
+// gamepadButtonNames are the control names of the buttons of the standard layout, by their Xbox names
+var gamepadButtonNames = map[ebiten.StandardGamepadButton]string{
+	ebiten.StandardGamepadButtonRightBottom:      "GamepadA",
+	ebiten.StandardGamepadButtonRightRight:       "GamepadB",
+	ebiten.StandardGamepadButtonRightLeft:        "GamepadX",
+	ebiten.StandardGamepadButtonRightTop:         "GamepadY",
+	ebiten.StandardGamepadButtonFrontTopLeft:     "GamepadLB",
+	ebiten.StandardGamepadButtonFrontTopRight:    "GamepadRB",
+	ebiten.StandardGamepadButtonFrontBottomLeft:  "GamepadLT",
+	ebiten.StandardGamepadButtonFrontBottomRight: "GamepadRT",
+	ebiten.StandardGamepadButtonCenterLeft:       "GamepadBack",
+	ebiten.StandardGamepadButtonCenterRight:      "GamepadStart",
+	ebiten.StandardGamepadButtonCenterCenter:     "GamepadHome",
+	ebiten.StandardGamepadButtonLeftStick:        "GamepadL3",
+	ebiten.StandardGamepadButtonRightStick:       "GamepadR3",
+	ebiten.StandardGamepadButtonLeftTop:          "GamepadUp",
+	ebiten.StandardGamepadButtonLeftBottom:       "GamepadDown",
+	ebiten.StandardGamepadButtonLeftLeft:         "GamepadLeft",
+	ebiten.StandardGamepadButtonLeftRight:        "GamepadRight",
+}
+
+// readFrame reads what the mouse, keyboard, touches and gamepads are doing this tick,
+// it is the only place the game asks ebiten about them
+func readFrame() input.Frame {
+	frame := input.Frame{Held: map[string]int{}}
+	frame.MouseX, frame.MouseY = ebiten.CursorPosition()
+	if ticks := inpututil.MouseButtonPressDuration(ebiten.MouseButtonLeft); ticks > 0 {
+		frame.Held[input.MouseLeft] = ticks
+	}
+
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	for _, key := range inpututil.AppendPressedKeys(nil) {
+		ticks := inpututil.KeyPressDuration(key)
+		frame.Held[key.String()] = ticks
+		if shift && key != ebiten.KeyShift && key != ebiten.KeyShiftLeft && key != ebiten.KeyShiftRight {
+			frame.Held[input.ShiftPrefix+key.String()] = ticks
+		}
+	}
+
+	for _, id := range ebiten.AppendTouchIDs(nil) {
+		x, y := ebiten.TouchPosition(id)
+		frame.Touches = append(frame.Touches, input.Touch{ID: int(id), X: x, Y: y, Ticks: inpututil.TouchPressDuration(id)})
+	}
+
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for button, name := range gamepadButtonNames {
+			// a button just pressed on one gamepad counts even when it is held on another
+			if ticks := inpututil.StandardGamepadButtonPressDuration(id, button); ticks > 0 && (frame.Held[name] == 0 || ticks < frame.Held[name]) {
+				frame.Held[name] = ticks
+			}
+		}
+		// the stick pushed furthest is the one that moves the cursor
+		x := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+		y := ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical)
+		if math.Hypot(x, y) > math.Hypot(frame.StickX, frame.StickY) {
+			frame.StickX, frame.StickY = x, y
+		}
+	}
+	return frame
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/tliddle1/hexloop/draw"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+// everything is designed for a screenWidth by screenHeight screen and scaled to fit the actual screen
+
+// resize lays the game out for a screen of the given size
+// hexes are resized in place so the board, loops and animations keep pointing at the same hexes
+func (this *Game) resize(width, height int) {
+	this.ScreenWidth, this.ScreenHeight = width, height
+	this.layoutScale = min(float64(width)/screenWidth, float64(height)/float64(screenHeight))
+	this.effects.layoutScale = this.layoutScale
+
+	origin := this.getGameBoardFirstHexCoordinate()
+	for _, hex := range this.hexes {
+		hex.Resize(origin[0], origin[1], this.px(hexagon.HexVertexRadius), draw.HexagonStrokeWidth(this.layoutScale), draw.ConnectionWidth(this.layoutScale))
+	}
+	// the hexes moved without their connections changing
+	this.boardCache.Invalidate()
+
+	centerX := float64(width) / 2
+	for _, hex := range this.titleHexes {
+		this.resizeButton(hex, centerX, float64(height)/2-this.px(hexagon.HexVertexRadiusTest*2.5))
+	}
+	this.resizeButton(this.tutorialStartButton, centerX, float64(height)-this.px(hexagon.HexVertexRadiusTest*1.5))
+	for _, button := range []*hexagon.TextHexagon{this.themesUploadButton, this.themesBackButton} {
+		this.resizeButton(button, centerX, float64(height)-this.px(hexagon.HexVertexRadiusTest*1.5))
+	}
+	for _, button := range []*hexagon.TextHexagon{this.settingsResetButton, this.settingsBackButton} {
+		this.resizeButton(button, centerX, float64(height)-this.px(hexagon.HexVertexRadiusTest*3))
+	}
+	for _, button := range []*hexagon.TextHexagon{this.resumeButton, this.restartButton, this.quitButton} {
+		this.resizeButton(button, centerX, float64(height)/2+this.px(hexagon.HexVertexRadiusTest))
+	}
+	for _, button := range []*hexagon.TextHexagon{this.playAgainButton, this.menuButton} {
+		this.resizeButton(button, centerX, float64(height)-this.px(hexagon.HexVertexRadiusTest*3))
+	}
+	this.generateTitleBoardImage(width, height)
+	this.generateThemePreviews()
+}
+
+func (this *Game) resizeButton(button *hexagon.TextHexagon, originX, originY float64) {
+	button.Resize(originX, originY, this.px(hexagon.HexVertexRadiusTest), draw.TitleHexagonStrokeWidth(this.layoutScale), draw.TitleConnectionWidth(this.layoutScale))
+}
+
+// px converts a size in design pixels to screen pixels
+func (this *Game) px(size float64) float64 {
+	return size * this.layoutScale
+}
+
+// screenOffset returns where the design sized screen starts when it is centered in the actual screen
+func (this *Game) screenOffset() (x, y float64) {
+	return (float64(this.ScreenWidth) - this.px(screenWidth)) / 2, (float64(this.ScreenHeight) - this.px(float64(screenHeight))) / 2
+}
+
+// getGameBoardFirstHexCoordinate returns the origin of the board, centered in the screen
+func (this *Game) getGameBoardFirstHexCoordinate() hexagon.Coordinate {
+	offsetX, offsetY := this.screenOffset()
+	xBuffer := offsetX + this.px(marginSize+hexagon.HexSideRadius)
+	yBuffer := offsetY + this.px(marginSize+hexagon.HexVertexRadius+smallTextSize*2)
+	return hexagon.Coordinate{xBuffer, yBuffer}
+}
+
+// newBoardHexes returns an empty board laid out for the current screen
+func (this *Game) newBoardHexes() []*hexagon.Hex {
+	return newHexes(rows, cols, this.px(hexagon.HexVertexRadius), draw.HexagonStrokeWidth(this.layoutScale), draw.ConnectionWidth(this.layoutScale), this.getGameBoardFirstHexCoordinate())
+}
+
+// newButton returns a title sized hexagon at the design size, resize moves it into place
+func newButton(col, row int, str string, textSize float64) *hexagon.TextHexagon {
+	return hexagon.NewTextHexagon(col, row, 0, 0, hexagon.HexVertexRadiusTest, draw.TitleHexagonStrokeWidth(1), draw.TitleConnectionWidth(1), str, textSize)
+}
+
+func toggleFullscreen() {
+	ebiten.SetFullscreen(!ebiten.IsFullscreen())
+}
+
+func (this *Game) nextArrowPosition() (x, y float32) {
+	return float32(float64(this.ScreenWidth) - this.px(35)), float32(this.px(marginSize + smallTextSize/2))
+}
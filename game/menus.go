@@ -0,0 +1,229 @@
+package game
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/tliddle1/hexloop/draw"
+	"github.com/tliddle1/hexloop/font"
+	"github.com/tliddle1/hexloop/hexagon"
+	"github.com/tliddle1/hexloop/input"
+	"github.com/tliddle1/hexloop/vector"
+)
+
+const (
+	pauseButtonSize    = smallTextSize * 2 / 3
+	pauseButtonCenterY = scoreTextY + smallTextSize*2/3
+	timerBarWidth      = smallTextSize * 4
+	timerBarHeight     = smallTextSize / 3
+	overlayAlpha       = 220
+)
+
+func newPauseMenuButtons() (resumeButton, restartButton, quitButton *hexagon.TextHexagon) {
+	resumeButton = newButton(-2, 0, "Resume", smallTextSize)
+	restartButton = newButton(0, 0, "Restart", smallTextSize)
+	quitButton = newButton(2, 0, "Menu", smallTextSize)
+	return resumeButton, restartButton, quitButton
+}
+
+func newGameOverButtons() (playAgainButton, menuButton *hexagon.TextHexagon) {
+	// odd columns are staggered down by half a hexagon
+	playAgainButton = newButton(-1, 0, "Again", smallTextSize)
+	menuButton = newButton(1, 0, "Menu", smallTextSize)
+	return playAgainButton, menuButton
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (this *Game) drawButton(screen *ebiten.Image, button *hexagon.TextHexagon) {
+	clr := this.theme.HexBorderColor
+	if button.Hovered {
+		clr = this.theme.PendingHexBorderColor
+	}
+	draw.TextHexagon(screen, button, clr, this.theme.ConnectionColor)
+}
+
+func (this *Game) drawCenteredText(screen *ebiten.Image, str string, textSize, y float64) {
+	drawOptions := &text.DrawOptions{}
+	drawOptions.GeoM.Translate(float64(this.ScreenWidth)/2, y)
+	drawOptions.ColorScale.ScaleWithColor(this.theme.ConnectionColor)
+	drawOptions.PrimaryAlign = text.AlignCenter
+	text.Draw(screen, str, font.Face(textSize), drawOptions)
+}
+
+func (this *Game) drawPauseButton(screen *ebiten.Image) {
+	clr := this.theme.ConnectionColor
+	if this.pauseButtonHovered || this.paused {
+		clr = this.theme.PendingHexBorderColor
+	}
+	x, y := this.pauseButtonPosition()
+	size := float32(this.px(pauseButtonSize))
+	barWidth := size / 3
+	vector.DrawFilledRect(screen, x, y, barWidth, size, clr, true)
+	vector.DrawFilledRect(screen, x+barWidth*2, y, barWidth, size, clr, true)
+}
+
+func (this *Game) drawTimer(screen *ebiten.Image) {
+	if this.mode != timeAttackMode {
+		return
+	}
+	clr := this.theme.ConnectionColor
+	if this.timeLeftTicks <= lowTimeSeconds*ticksPerSecond {
+		clr = this.theme.PendingConnectionColors[0]
+	}
+	face := font.Face(this.px(smallTextSize))
+	pauseX, _ := this.pauseButtonPosition()
+	textX := float64(pauseX) - this.px(marginSize/2)
+	drawOptions := &text.DrawOptions{}
+	drawOptions.GeoM.Translate(textX, this.px(scoreTextY))
+	drawOptions.ColorScale.ScaleWithColor(clr)
+	drawOptions.PrimaryAlign = text.AlignEnd
+	text.Draw(screen, timeString(this.timeLeftTicks), face, drawOptions)
+
+	// the bar is full at the starting time and bonus time past that is only shown in the text
+	barWidth, barHeight := float32(this.px(timerBarWidth)), float32(this.px(timerBarHeight))
+	barX := float32(textX-text.Advance("0:00", face)-this.px(marginSize/2)) - barWidth
+	barY := float32(this.px(pauseButtonCenterY)) - barHeight/2
+	fraction := min(float32(this.timeLeftTicks)/(timeAttackSeconds*ticksPerSecond), 1)
+	vector.DrawFilledRect(screen, barX, barY, barWidth*fraction, barHeight, clr, true)
+	vector.StrokeRect(screen, barX, barY, barWidth, barHeight, draw.HexagonStrokeWidth(this.layoutScale), this.theme.ConnectionColor, true)
+}
+
+// drawMoveTimer draws the time left for a blitz move as a ring that shrinks around the hovered hex
+// or next to the pause button when no hex is hovered
+func (this *Game) drawMoveTimer(screen *ebiten.Image, hoveredHex *hexagon.Hex) {
+	if this.mode != blitzMode || this.disabledTicksLeft > 0 {
+		return
+	}
+	clr := this.theme.PendingHexBorderColor
+	if this.moveTicksLeft <= ticksPerSecond {
+		clr = this.theme.PendingConnectionColors[0]
+	}
+	var x, y, radius float32
+	if hoveredHex != nil {
+		x, y = float32(hoveredHex.Center[0]), float32(hoveredHex.Center[1])
+		radius = float32(hoveredHex.VertexRadius + float64(draw.HexagonStrokeWidth(this.layoutScale)*2))
+	} else {
+		pauseX, _ := this.pauseButtonPosition()
+		radius = float32(this.px(pauseButtonSize / 2))
+		x, y = pauseX-float32(this.px(marginSize/2))-radius, float32(this.px(pauseButtonCenterY))
+	}
+	fraction := float32(this.moveTicksLeft) / (blitzMoveSeconds * ticksPerSecond)
+	if fraction <= 0 {
+		return
+	}
+	startAngle := float32(-math.Pi / 2)
+	vector.StrokePartialCircle(screen, x, y, radius, startAngle, startAngle+2*math.Pi*fraction, draw.ConnectionWidth(this.layoutScale), clr, true)
+}
+
+func (this *Game) drawPauseMenu(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, float32(this.ScreenWidth), float32(this.ScreenHeight), transparent(this.theme.BackgroundColor, overlayAlpha), false)
+	this.drawCenteredText(screen, "Paused", this.px(smallTextSize*2), float64(this.ScreenHeight)/2-this.px(hexagon.HexVertexRadiusTest*2))
+	this.drawButton(screen, this.resumeButton)
+	this.drawButton(screen, this.restartButton)
+	this.drawButton(screen, this.quitButton)
+}
+
+func (this *Game) drawGameOverScreen(screen *ebiten.Image) {
+	heading := "Game Over"
+	if this.mode == timeAttackMode && this.timeLeftTicks == 0 {
+		heading = "Time's Up!"
+	}
+	y := this.px(marginSize * 2)
+	this.drawCenteredText(screen, heading, this.px(smallTextSize*2), y)
+	y += this.px(smallTextSize * 4)
+	this.drawCenteredText(screen, this.scoreString(this.score), this.px(smallTextSize), y)
+	y += this.px(smallTextSize * 2)
+	this.drawCenteredText(screen, this.highScoreString(this.highScore), this.px(smallTextSize), y)
+	y += this.px(smallTextSize * 2)
+	this.drawCenteredText(screen, bestStreakString(this.bestStreak), this.px(smallTextSize), y)
+	this.drawButton(screen, this.playAgainButton)
+	this.drawButton(screen, this.menuButton)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// updatePauseButton hovers the pause button under the mouse, reporting whether it was tapped
+func (this *Game) updatePauseButton() (tapped bool) {
+	mouseX, mouseY := this.input.Mouse()
+	x, y := this.pauseButtonPosition()
+	size := float32(this.px(pauseButtonSize))
+	this.pauseButtonHovered = rectContains(mouseX, mouseY, x, y, size, size, 0)
+	if tapX, tapY, ok := this.justTapped(); ok {
+		return rectContains(tapX, tapY, x, y, size, size, float32(this.touchTargetRadius()))
+	}
+	return false
+}
+
+func (this *Game) updatePauseMenu() {
+	mouseX, mouseY := this.input.Mouse()
+	updateButtonHovered(this.resumeButton, mouseX, mouseY)
+	updateButtonHovered(this.restartButton, mouseX, mouseY)
+	updateButtonHovered(this.quitButton, mouseX, mouseY)
+	pressed := this.updateFocus(&this.resumeButton.Hovered, &this.restartButton.Hovered, &this.quitButton.Hovered)
+	tapped := this.updateButtonsTapped(this.resumeButton, this.restartButton, this.quitButton)
+	if !this.input.JustPressed(input.Click) && !pressed && !tapped {
+		return
+	}
+	if this.resumeButton.Hovered {
+		this.paused = false
+	}
+	if this.restartButton.Hovered {
+		this.startGame(this.mode)
+	}
+	if this.quitButton.Hovered {
+		this.paused = false
+		this.gameInProgress = false
+		this.currentSceneType = titleScreen
+	}
+}
+
+func (this *Game) updateGameOverScreen() {
+	mouseX, mouseY := this.input.Mouse()
+	updateButtonHovered(this.playAgainButton, mouseX, mouseY)
+	updateButtonHovered(this.menuButton, mouseX, mouseY)
+	pressed := this.updateFocus(&this.playAgainButton.Hovered, &this.menuButton.Hovered)
+	tapped := this.updateButtonsTapped(this.playAgainButton, this.menuButton)
+	if !this.input.JustPressed(input.Click) && !pressed && !tapped {
+		return
+	}
+	if this.playAgainButton.Hovered {
+		this.startGame(this.mode)
+	}
+	if this.menuButton.Hovered {
+		this.currentSceneType = titleScreen
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (this *Game) pauseButtonPosition() (x, y float32) {
+	return float32(float64(this.ScreenWidth) - this.px(10+pauseButtonSize)), float32(this.px(pauseButtonCenterY - pauseButtonSize/2))
+}
+
+func updateButtonHovered(button *hexagon.TextHexagon, mouseX, mouseY int) {
+	button.Hovered = button.PointInHexagon(float64(mouseX), float64(mouseY))
+}
+
+// timeString formats a number of ticks as minutes and seconds, rounding up to the next whole second
+func timeString(ticks int) string {
+	seconds := (ticks + ticksPerSecond - 1) / ticksPerSecond
+	secondsStr := strconv.Itoa(seconds % 60)
+	if len(secondsStr) < 2 {
+		secondsStr = "0" + secondsStr
+	}
+	return strconv.Itoa(seconds/60) + ":" + secondsStr
+}
+
+// transparent returns clr with the given alpha, premultiplied as ebiten expects
+func transparent(clr color.RGBA, alpha uint8) color.RGBA {
+	return color.RGBA{
+		R: uint8(uint16(clr.R) * uint16(alpha) / 255),
+		G: uint8(uint16(clr.G) * uint16(alpha) / 255),
+		B: uint8(uint16(clr.B) * uint16(alpha) / 255),
+		A: alpha,
+	}
+}
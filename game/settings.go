@@ -0,0 +1,211 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/tliddle1/hexloop/font"
+	"github.com/tliddle1/hexloop/hexagon"
+	"github.com/tliddle1/hexloop/input"
+	"github.com/tliddle1/hexloop/storage"
+)
+
+const (
+	bindingsStorageKey = "bindings"
+	// the table of bindings, in design pixels
+	settingsTextSize  = smallTextSize * 2 / 3
+	settingsHeaderY   = marginSize + smallTextSize*2 + marginSize/4
+	settingsRowHeight = smallTextSize * 3 / 4
+	settingsRowsY     = settingsHeaderY + settingsRowHeight*3/2
+	settingsActionX   = marginSize * 2
+	settingsKeysX     = screenWidth * 2 / 5
+	settingsGamepadX  = screenWidth * 7 / 10
+)
+
+// loadBindings returns the bindings the player saved last time, or the default bindings
+func loadBindings() input.Bindings {
+	data, err := storage.Load(bindingsStorageKey)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Println(err)
+		}
+		return input.DefaultBindings()
+	}
+	bindings, unknown, err := input.ParseBindings([]byte(data))
+	if err != nil {
+		log.Println(err)
+		return input.DefaultBindings()
+	}
+	for _, name := range unknown {
+		log.Printf("bindings: skipping unknown action %q", name)
+	}
+	return bindings
+}
+
+// saveBindings saves the bindings so they are there next time
+func (this *Game) saveBindings() {
+	data, err := json.Marshal(this.input.Bindings)
+	if err == nil {
+		err = storage.Save(bindingsStorageKey, string(data))
+	}
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func newSettingsButtons() (resetButton, backButton *hexagon.TextHexagon) {
+	resetButton = newButton(-1, 0, "Reset", smallTextSize)
+	backButton = newButton(1, 0, "Back", smallTextSize)
+	return resetButton, backButton
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// drawSettingsScreen draws a row for each action with the key and the gamepad control bound to it
+func (this *Game) drawSettingsScreen(screen *ebiten.Image) {
+	offsetX, offsetY := this.screenOffset()
+	this.drawCenteredText(screen, "Settings", this.px(smallTextSize*2), offsetY+this.px(marginSize))
+	if this.rebinding >= 0 {
+		action := input.Actions()[this.rebinding].String()
+		prompt := "Press a key or button for " + action + ", or Back to cancel"
+		if this.rebindTaken != "" {
+			prompt = input.Label(this.rebindTaken) + " does another action, press another key or button for " + action
+		}
+		this.drawCenteredText(screen, prompt, this.px(settingsTextSize), offsetY+this.px(settingsHeaderY))
+	} else {
+		this.drawSettingsRow(screen, offsetX, offsetY+this.px(settingsHeaderY), "Action", "Keyboard", "Gamepad", false)
+	}
+	for i, action := range input.Actions() {
+		keys, gamepad := "-", "-"
+		if controls := this.input.Bindings.Keys(action); len(controls) > 0 {
+			keys = input.Label(controls[0])
+		}
+		if controls := this.input.Bindings.Gamepad(action); len(controls) > 0 {
+			gamepad = input.Label(controls[0])
+		}
+		if i == this.rebinding {
+			keys, gamepad = "...", "..."
+		}
+		_, y, _, _ := this.actionRowRect(i)
+		highlighted := i == this.rebinding || i == this.hoveredAction || this.actionFocused[i]
+		this.drawSettingsRow(screen, offsetX, float64(y), action.String(), keys, gamepad, highlighted)
+	}
+	this.drawButton(screen, this.settingsResetButton)
+	this.drawButton(screen, this.settingsBackButton)
+}
+
+func (this *Game) drawSettingsRow(screen *ebiten.Image, offsetX, y float64, action, keys, gamepad string, highlighted bool) {
+	clr := this.theme.ConnectionColor
+	if highlighted {
+		clr = this.theme.PendingHexBorderColor
+	}
+	face := font.Face(this.px(settingsTextSize))
+	columns := []struct {
+		str string
+		x   float64
+	}{
+		{action, settingsActionX},
+		{keys, settingsKeysX},
+		{gamepad, settingsGamepadX},
+	}
+	for _, column := range columns {
+		drawOptions := &text.DrawOptions{}
+		drawOptions.GeoM.Translate(offsetX+this.px(column.x), y)
+		drawOptions.ColorScale.ScaleWithColor(clr)
+		text.Draw(screen, column.str, face, drawOptions)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// updateSettingsScreen picks an action to rebind with the mouse, a tap or the focus,
+// or resets the bindings to the defaults
+func (this *Game) updateSettingsScreen() {
+	if this.rebinding >= 0 {
+		this.updateRebinding()
+		return
+	}
+	mouseX, mouseY := this.input.Mouse()
+	updateButtonHovered(this.settingsResetButton, mouseX, mouseY)
+	updateButtonHovered(this.settingsBackButton, mouseX, mouseY)
+	this.hoveredAction = this.actionAt(mouseX, mouseY)
+	clear(this.actionFocused)
+	var hovered []*bool
+	for i := range this.actionFocused {
+		hovered = append(hovered, &this.actionFocused[i])
+	}
+	pressed := this.updateFocus(append(hovered, &this.settingsResetButton.Hovered, &this.settingsBackButton.Hovered)...)
+	if this.focus >= 0 {
+		this.hoveredAction = -1
+	}
+	tapped := this.updateButtonsTapped(this.settingsResetButton, this.settingsBackButton)
+	if x, y, ok := this.justTapped(); ok && !tapped {
+		this.hoveredAction = this.actionAt(x, y)
+		tapped = this.hoveredAction >= 0
+	}
+	if this.input.JustPressed(input.Back) {
+		this.currentSceneType = titleScreen
+		return
+	}
+	if !this.input.JustPressed(input.Click) && !pressed && !tapped {
+		return
+	}
+	if this.hoveredAction >= 0 {
+		this.rebinding = this.hoveredAction
+	}
+	if this.focus >= 0 && this.focus < len(this.actionFocused) {
+		this.rebinding = this.focus
+	}
+	if this.settingsResetButton.Hovered {
+		this.input.Bindings = input.DefaultBindings()
+		this.saveBindings()
+	}
+	if this.settingsBackButton.Hovered {
+		this.currentSceneType = titleScreen
+	}
+}
+
+// updateRebinding binds the next key or gamepad control pressed to the action being rebound, Back, a click or a tap cancels.
+// A control another action has can't be bound when the action has no control of its own to swap it for.
+func (this *Game) updateRebinding() {
+	if this.input.JustPressed(input.Back) || this.input.JustPressed(input.Click) || this.input.JustPressed(input.Tap) {
+		this.rebinding = -1
+		this.rebindTaken = ""
+		return
+	}
+	control, ok := this.input.JustPressedControl()
+	if !ok {
+		return
+	}
+	if !this.input.Bindings.Bind(input.Actions()[this.rebinding], control) {
+		this.rebindTaken = control
+		return
+	}
+	this.rebinding = -1
+	this.rebindTaken = ""
+	this.saveBindings()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// actionAt returns the index of the action whose row is at the screen position, -1 if there is none
+func (this *Game) actionAt(x, y int) int {
+	for i := range this.actionFocused {
+		left, top, width, height := this.actionRowRect(i)
+		if rectContains(x, y, left, top, width, height, 0) {
+			return i
+		}
+	}
+	return -1
+}
+
+// actionRowRect returns where the i-th action's row is drawn
+func (this *Game) actionRowRect(i int) (x, y, width, height float32) {
+	offsetX, offsetY := this.screenOffset()
+	x = float32(offsetX + this.px(marginSize))
+	y = float32(offsetY + this.px(settingsRowsY+float64(i)*settingsRowHeight))
+	return x, y, float32(this.px(screenWidth - marginSize*2)), float32(this.px(settingsRowHeight))
+}
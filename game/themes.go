@@ -0,0 +1,440 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/draw"
+	"github.com/tliddle1/hexloop/font"
+	"github.com/tliddle1/hexloop/hexagon"
+	"github.com/tliddle1/hexloop/input"
+	"github.com/tliddle1/hexloop/storage"
+	"github.com/tliddle1/hexloop/vector"
+)
+
+const (
+	themeStorageKey = "theme"
+	userThemesDir   = "themes" // storage directory of the theme files the player added
+	themeFileExt    = ".json"
+	// theme cards, in design pixels
+	themeColumns             = 4
+	themeCardWidth           = (screenWidth - marginSize*(themeColumns+1)) / themeColumns
+	themeCardHeight          = themeCardWidth * 2 / 3
+	themeRowHeight           = themeCardHeight + smallTextSize*2 // room for the name under the card
+	themeNameTextSize        = smallTextSize * 2 / 3
+	themeCardsY              = marginSize*2 + smallTextSize*3
+	themePreviewVertexRadius = 12
+	themeHintY               = marginSize + smallTextSize*2 + marginSize/4
+	themeHintTextSize        = smallTextSize * 2 / 3
+	themeHintMaxLines        = 2
+	shimmerAlpha             = 160 // of the shimmer at the center of its band
+)
+
+// themePreviewPendingConnections are drawn on the preview's pending hex so each pending color shows
+var themePreviewPendingConnections = []hexagon.Connection{{0, 3}, {1, 4}, {2, 5}}
+
+// newThemes returns the built-in themes followed by the themes the player added,
+// theme files that can't be loaded are logged and skipped
+func newThemes() []*color2.Theme {
+	themes := color2.Themes()
+	keys, err := storage.List(userThemesDir)
+	if err != nil {
+		log.Println(err)
+	}
+	for _, key := range keys {
+		if path.Ext(key) != themeFileExt {
+			continue
+		}
+		data, err := storage.Load(key)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		theme, err := color2.ParseTheme([]byte(data))
+		if err == nil {
+			themes, err = withTheme(themes, theme)
+		}
+		if err != nil {
+			log.Printf("%s: %v", key, err)
+			continue
+		}
+		for _, warning := range theme.CheckContrast(color2.MinContrastRatio) {
+			log.Printf("%s: %v", key, warning)
+		}
+	}
+	return themes
+}
+
+// withTheme adds the theme to themes, replacing an added theme with the same name
+// built-in themes can't be replaced
+func withTheme(themes []*color2.Theme, theme *color2.Theme) ([]*color2.Theme, error) {
+	if _, ok := color2.ThemeByName(theme.Name); ok {
+		return themes, fmt.Errorf("%q is the name of a built-in theme", theme.Name)
+	}
+	for i := range themes {
+		if themes[i].Name == theme.Name {
+			themes[i] = theme
+			return themes, nil
+		}
+	}
+	return append(themes, theme), nil
+}
+
+// loadTheme returns the theme the player picked last time, or the default theme
+func loadTheme(themes []*color2.Theme) *color2.Theme {
+	name, err := storage.Load(themeStorageKey)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Println(err)
+		}
+		return color2.NewDefaultTheme()
+	}
+	for _, theme := range themes {
+		if theme.Name == name {
+			return theme
+		}
+	}
+	return color2.NewDefaultTheme()
+}
+
+// setTheme switches to the theme and saves it as the player's pick
+func (this *Game) setTheme(theme *color2.Theme) {
+	this.theme = theme
+	this.generateTitleBoardImage(this.ScreenWidth, this.ScreenHeight)
+	if err := storage.Save(themeStorageKey, theme.Name); err != nil {
+		log.Println(err)
+	}
+}
+
+// importTheme adds the theme in a dropped or uploaded theme file and saves the file so the theme is there next time
+func (this *Game) importTheme(fileName string, data []byte) (*color2.Theme, error) {
+	if path.Ext(fileName) != themeFileExt {
+		return nil, fmt.Errorf("theme files end in %s", themeFileExt)
+	}
+	theme, err := color2.ParseTheme(data)
+	if err != nil {
+		return nil, err
+	}
+	if this.themes, err = withTheme(this.themes, theme); err != nil {
+		return nil, err
+	}
+	if err := storage.Save(path.Join(userThemesDir, fileName), string(data)); err != nil {
+		log.Println(err)
+	}
+	this.setTheme(theme)
+	return theme, nil
+}
+
+// themeFileData is a theme file the player added and what went wrong reading it
+type themeFileData struct {
+	name string
+	data []byte
+	err  error
+}
+
+// newThemesButtons returns the themes screen's buttons, Upload is only shown where themes can be uploaded
+func newThemesButtons() (uploadButton, backButton *hexagon.TextHexagon) {
+	if !canUploadThemes {
+		return newButton(-1, 0, "Upload", smallTextSize), newButton(0, 0, "Back", smallTextSize)
+	}
+	return newButton(-1, 0, "Upload", smallTextSize), newButton(1, 0, "Back", smallTextSize)
+}
+
+// themesButtons returns the buttons shown on the themes screen
+func (this *Game) themesButtons() []*hexagon.TextHexagon {
+	if !canUploadThemes {
+		return []*hexagon.TextHexagon{this.themesBackButton}
+	}
+	return []*hexagon.TextHexagon{this.themesUploadButton, this.themesBackButton}
+}
+
+// newThemePreviewHexes returns a small board with placed tiles, a completed loop around the vertex between
+// three hexes, and an empty hex to draw a pending tile on
+func newThemePreviewHexes(vertexRadius float64, edgeWidth, connectionWidth float32) (hexes []*hexagon.Hex, loop hexagon.Loop, pending *hexagon.Hex) {
+	connections := map[[2]int][]hexagon.Connection{
+		{0, 0}: connectionPermutations[3],
+		{1, 0}: {{1, 2}, {0, 3}, {4, 5}},
+		{2, 0}: connectionPermutations[10],
+		{3, 0}: {{3, 4}, {0, 1}, {2, 5}},
+		{4, 0}: connectionPermutations[5],
+		{6, 0}: connectionPermutations[13],
+		{0, 1}: connectionPermutations[8],
+		{2, 1}: {{0, 5}, {1, 4}, {2, 3}},
+		{6, 1}: connectionPermutations[1],
+	}
+	for row := range 2 {
+		for col := range 7 {
+			// only even columns of the second row so the preview stays short
+			if row == 1 && col%2 != 0 {
+				continue
+			}
+			hex := hexagon.NewHex(col, row, 0, 0, vertexRadius, edgeWidth, connectionWidth)
+			hex.Connections = connections[[2]int{col, row}]
+			hexes = append(hexes, hex)
+		}
+	}
+	find := func(col, row int) *hexagon.Hex {
+		for _, hex := range hexes {
+			if hex.Col == col && hex.Row == row {
+				return hex
+			}
+		}
+		return nil
+	}
+	loop = hexagon.Loop{
+		{Hex: find(1, 0), Connection: hexagon.Connection{1, 2}},
+		{Hex: find(2, 1), Connection: hexagon.Connection{0, 5}},
+		{Hex: find(3, 0), Connection: hexagon.Connection{3, 4}},
+	}
+	return hexes, loop, find(5, 0)
+}
+
+// generateThemePreviews draws each theme's sample board into a card sized image
+func (this *Game) generateThemePreviews() {
+	for _, preview := range this.themePreviews {
+		preview.Deallocate()
+	}
+	this.themePreviews = this.themePreviews[:0]
+
+	width, height := int(this.px(themeCardWidth)), int(this.px(themeCardHeight))
+	hexes, loop, pending := newThemePreviewHexes(this.px(themePreviewVertexRadius), draw.HexagonStrokeWidth(this.layoutScale), draw.ConnectionWidth(this.layoutScale))
+	// center the board in the card
+	minX, minY, maxX, maxY := hexagon.Bounds(hexes)
+	originX := (float64(width)-(maxX-minX))/2 - minX
+	originY := (float64(height)-(maxY-minY))/2 - minY
+	for _, hex := range hexes {
+		hex.Resize(originX, originY, hex.VertexRadius, hex.EdgeWidth, hex.ConnectionWidth)
+	}
+
+	for _, theme := range this.themes {
+		img := ebiten.NewImage(width, height)
+		drawThemePreview(img, theme, hexes, loop, pending)
+		this.themePreviews = append(this.themePreviews, img)
+	}
+}
+
+func drawThemePreview(dst *ebiten.Image, theme *color2.Theme, hexes []*hexagon.Hex, loop hexagon.Loop, pending *hexagon.Hex) {
+	dst.Fill(theme.BackgroundColor)
+	for _, hex := range hexes {
+		if hex != pending {
+			draw.Hexagon(dst, hex, theme.HexBorderColor)
+		}
+	}
+	draw.Hexagon(dst, pending, theme.PendingHexBorderColor)
+	for _, hex := range hexes {
+		draw.HexagonConnections(dst, hex, theme.ConnectionColor, theme)
+	}
+	for i, connection := range themePreviewPendingConnections {
+		draw.PatternedConnection(dst, pending, connection, pendingPattern(theme, i), theme.PendingConnectionColors[i%len(theme.PendingConnectionColors)], theme.BackgroundColor)
+	}
+	drawLoops(dst, []hexagon.Loop{loop}, theme)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func (this *Game) drawThemesScreen(screen *ebiten.Image) {
+	_, offsetY := this.screenOffset()
+	this.drawCenteredText(screen, "Themes", this.px(smallTextSize*2), offsetY+this.px(marginSize))
+	this.drawThemeHint(screen)
+	face := font.Face(this.px(themeNameTextSize))
+	for i, theme := range this.themes {
+		x, y, width, height := this.themeCardRect(i)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(x), float64(y))
+		screen.DrawImage(this.themePreviews[i], op)
+
+		clr := this.theme.HexBorderColor
+		strokeWidth := draw.HexagonStrokeWidth(this.layoutScale)
+		if i == this.hoveredTheme {
+			clr = this.theme.PendingHexBorderColor
+		}
+		if theme.Name == this.theme.Name {
+			clr = this.theme.ConnectionColor
+			strokeWidth = draw.TitleHexagonStrokeWidth(this.layoutScale)
+		}
+		vector.StrokeRect(screen, x, y, width, height, strokeWidth, clr, true)
+
+		drawOptions := &text.DrawOptions{}
+		drawOptions.GeoM.Translate(float64(x+width/2), float64(y+height)+this.px(marginSize/4))
+		drawOptions.ColorScale.ScaleWithColor(this.theme.ConnectionColor)
+		drawOptions.PrimaryAlign = text.AlignCenter
+		text.Draw(screen, theme.Name, face, drawOptions)
+	}
+	for _, button := range this.themesButtons() {
+		this.drawButton(screen, button)
+	}
+}
+
+// drawThemeHint explains how to add a theme, or what was wrong with the last theme files dropped
+func (this *Game) drawThemeHint(screen *ebiten.Image) {
+	str := "Drop a " + themeFileExt + " theme file here to add it"
+	if canUploadThemes {
+		str = "Drop or upload a " + themeFileExt + " theme file to add it"
+	}
+	clr := this.theme.ConnectionColor
+	if this.themeError != nil {
+		str = this.themeError.Error()
+		clr = this.theme.PendingConnectionColors[0]
+	}
+	face := font.Face(this.px(themeHintTextSize))
+	lines := hexagon.Wrap(strings.ReplaceAll(str, "\n", " "), this.px(screenWidth-marginSize*2), func(str string) float64 {
+		return text.Advance(str, face)
+	})
+	if len(lines) > themeHintMaxLines {
+		lines = append(lines[:themeHintMaxLines-1], lines[themeHintMaxLines-1]+"...")
+	}
+	_, offsetY := this.screenOffset()
+	drawOptions := &text.DrawOptions{}
+	drawOptions.GeoM.Translate(float64(this.ScreenWidth)/2, offsetY+this.px(themeHintY))
+	drawOptions.ColorScale.ScaleWithColor(clr)
+	drawOptions.PrimaryAlign = text.AlignCenter
+	drawOptions.LineSpacing = font.LineHeight(face)
+	text.Draw(screen, strings.Join(lines, "\n"), face, drawOptions)
+}
+
+// drawShimmer draws the theme's shimmer over the hex borders where its band is crossing the screen
+func (this *Game) drawShimmer(screen *ebiten.Image, hexes []*hexagon.Hex) {
+	if this.theme.ShimmerColor.A == 0 {
+		return
+	}
+	for _, hex := range hexes {
+		strength := this.theme.ShimmerAt(hex.Center[0] / float64(this.ScreenWidth))
+		if strength > 0 {
+			draw.Hexagon(screen, hex, transparent(this.theme.ShimmerColor, uint8(shimmerAlpha*strength)))
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// updateTheme animates the theme, the board cache notices the new colors itself but the title board has to be redrawn
+func (this *Game) updateTheme() {
+	this.ticks++
+	if this.theme.Update(this.ticks) {
+		this.titleBoardStale = true
+	}
+}
+
+func (this *Game) updateThemesScreen() {
+	mouseX, mouseY := this.input.Mouse()
+	this.hoveredTheme = this.themeAt(mouseX, mouseY)
+	this.updateDroppedThemes()
+	if files := takeUploadedThemeFiles(); len(files) > 0 {
+		this.importThemeFiles(files)
+	}
+	// the theme cards come before the buttons in the focus order
+	cardFocused := make([]bool, len(this.themes))
+	var hovered []*bool
+	for i := range cardFocused {
+		hovered = append(hovered, &cardFocused[i])
+	}
+	for _, button := range this.themesButtons() {
+		updateButtonHovered(button, mouseX, mouseY)
+		hovered = append(hovered, &button.Hovered)
+	}
+	pressed := this.updateFocus(hovered...)
+	if this.focus >= 0 {
+		// the focused card is highlighted like the card under the mouse, -1 when a button is focused
+		this.hoveredTheme = slices.Index(cardFocused, true)
+	}
+	tapped := this.updateButtonsTapped(this.themesButtons()...)
+	if x, y, ok := this.justTapped(); ok && !tapped {
+		this.hoveredTheme = this.themeAt(x, y)
+		tapped = this.hoveredTheme >= 0
+	}
+	if this.input.JustPressed(input.Back) {
+		this.currentSceneType = titleScreen
+		return
+	}
+	if !this.input.JustPressed(input.Click) && !pressed && !tapped {
+		return
+	}
+	if this.hoveredTheme >= 0 {
+		this.setTheme(this.themes[this.hoveredTheme])
+	}
+	if canUploadThemes && this.themesUploadButton.Hovered {
+		openThemePicker()
+	}
+	if this.themesBackButton.Hovered {
+		this.currentSceneType = titleScreen
+	}
+}
+
+// updateDroppedThemes imports the theme files dropped onto the game
+func (this *Game) updateDroppedThemes() {
+	dropped := ebiten.DroppedFiles()
+	if dropped == nil {
+		return
+	}
+	entries, err := fs.ReadDir(dropped, ".")
+	if err != nil {
+		this.themeError = err
+		return
+	}
+	var files []themeFileData
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(dropped, entry.Name())
+		files = append(files, themeFileData{name: entry.Name(), data: data, err: err})
+	}
+	this.importThemeFiles(files)
+}
+
+// importThemeFiles imports the theme files the player dropped or uploaded,
+// keeping what went wrong and the colors that are hard to tell apart to show the player
+func (this *Game) importThemeFiles(files []themeFileData) {
+	var errs []error
+	for _, file := range files {
+		var theme *color2.Theme
+		err := file.err
+		if err == nil {
+			theme, err = this.importTheme(file.name, file.data)
+		}
+		if err != nil {
+			log.Printf("%s: %v", file.name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", file.name, err))
+			continue
+		}
+		for _, warning := range theme.CheckContrast(color2.MinContrastRatio) {
+			log.Printf("%s: %v", file.name, warning)
+			errs = append(errs, fmt.Errorf("%s: %v", file.name, warning))
+		}
+	}
+	this.themeError = errors.Join(errs...)
+	this.generateThemePreviews()
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// themeAt returns the index of the theme whose card is at the screen position, -1 if there is none
+func (this *Game) themeAt(x, y int) int {
+	for i := range this.themes {
+		left, top, width, height := this.themeCardRect(i)
+		if rectContains(x, y, left, top, width, height, 0) {
+			return i
+		}
+	}
+	return -1
+}
+
+// themeCardRect returns where the i-th theme's card is drawn, rows that aren't full are centered
+func (this *Game) themeCardRect(i int) (x, y, width, height float32) {
+	offsetX, offsetY := this.screenOffset()
+	col, row := i%themeColumns, i/themeColumns
+	cardsInRow := min(themeColumns, len(this.themes)-row*themeColumns)
+	rowWidth := float64(cardsInRow)*themeCardWidth + float64(cardsInRow-1)*marginSize
+	left := (screenWidth - rowWidth) / 2
+	x = float32(offsetX + this.px(left+float64(col)*(themeCardWidth+marginSize)))
+	y = float32(offsetY + this.px(themeCardsY+float64(row)*themeRowHeight))
+	return x, y, float32(this.px(themeCardWidth)), float32(this.px(themeCardHeight))
+}
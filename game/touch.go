@@ -0,0 +1,99 @@
+package game
+
+import (
+	"math"
+
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+// minTouchTargetSize is the smallest a control should be to tap reliably, in device independent pixels
+const minTouchTargetSize = 44
+
+// updateTouchCursor moves the cursor to the hex under a touch and follows the touch as it drags.
+// Tapping the hex the cursor is already on places the tile there, when the touch lifts without leaving the hex.
+func (this *Game) updateTouchCursor() {
+	touch, down := this.input.Touch(this.touchID)
+	if this.touching && !down {
+		this.touching = false
+		cursor := this.getHexFromGridPosition(this.cursorRow, this.cursorCol)
+		if this.touchPlaces && cursor != nil && cursor.Empty() {
+			this.placeTile(cursor)
+		}
+	}
+	if !this.touching {
+		tap, ok := this.input.Tap()
+		if !ok {
+			return
+		}
+		this.touching = true
+		this.touchID = tap.ID
+		hex := this.getHexAt(tap.X, tap.Y)
+		this.touchPlaces = hex != nil && this.cursorActive && hex.Row == this.cursorRow && hex.Col == this.cursorCol
+		this.moveTouchCursor(hex)
+		return
+	}
+	hex := this.getHexAt(touch.X, touch.Y)
+	if hex == nil || hex.Row != this.cursorRow || hex.Col != this.cursorCol {
+		// dragging only moves the tile, it takes another tap to place it
+		this.touchPlaces = false
+		this.moveTouchCursor(hex)
+	}
+}
+
+func (this *Game) moveTouchCursor(hex *hexagon.Hex) {
+	if hex == nil {
+		return
+	}
+	this.cursorActive = true
+	this.cursorRow, this.cursorCol = hex.Row, hex.Col
+}
+
+// justTapped returns where a touch started this tick
+func (this *Game) justTapped() (x, y int, ok bool) {
+	tap, ok := this.input.Tap()
+	return tap.X, tap.Y, ok
+}
+
+// updateButtonsTapped hovers the button tapped this tick, the closest one to the tap
+// within a touch target of its center or inside its hexagon, and reports whether one was
+func (this *Game) updateButtonsTapped(buttons ...*hexagon.TextHexagon) bool {
+	x, y, ok := this.justTapped()
+	if !ok {
+		return false
+	}
+	var tapped *hexagon.TextHexagon
+	closest := math.Inf(1)
+	for _, button := range buttons {
+		distance := math.Hypot(float64(x)-button.Center[0], float64(y)-button.Center[1])
+		if distance < closest && (distance <= this.touchTargetRadius() || button.PointInHexagon(float64(x), float64(y))) {
+			tapped, closest = button, distance
+		}
+	}
+	for _, button := range buttons {
+		button.Hovered = button == tapped
+	}
+	return tapped != nil
+}
+
+// touchTargetRadius is half of minTouchTargetSize in screen pixels
+func (this *Game) touchTargetRadius() float64 {
+	return minTouchTargetSize / 2 * max(this.deviceScale, 1)
+}
+
+// getHexAt returns the board hex at the screen position
+func (this *Game) getHexAt(x, y int) *hexagon.Hex {
+	for _, hex := range this.hexes {
+		if hex.PointInHexagon(float64(x), float64(y)) {
+			return hex
+		}
+	}
+	return nil
+}
+
+// rectContains reports whether the rectangle, grown to at least padding either side of its center, contains (x, y)
+func rectContains(x, y int, left, top, width, height, padding float32) bool {
+	centerX, centerY := left+width/2, top+height/2
+	halfWidth, halfHeight := max(width/2, padding), max(height/2, padding)
+	return float32(x) > centerX-halfWidth && float32(x) < centerX+halfWidth &&
+		float32(y) > centerY-halfHeight && float32(y) < centerY+halfHeight
+}
@@ -0,0 +1,12 @@
+//go:build !js
+
+package game
+
+// canUploadThemes is whether the themes screen has an Upload button, on the desktop theme files are dropped onto the window
+const canUploadThemes = false
+
+func openThemePicker() {}
+
+func takeUploadedThemeFiles() []themeFileData {
+	return nil
+}
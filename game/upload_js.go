@@ -0,0 +1,78 @@
+//go:build js
+
+package game
+
+import (
+	"errors"
+	"sync"
+	"syscall/js"
+)
+
+// canUploadThemes is whether the themes screen has an Upload button, phones can't drop files onto the page
+const canUploadThemes = true
+
+var (
+	uploadedThemeFiles  []themeFileData // read from the picker and not yet imported
+	uploadedThemeFilesM sync.Mutex
+)
+
+// openThemePicker opens the browser's file picker for theme files, takeUploadedThemeFiles returns them once they are read.
+// Browsers only open the picker soon after the player clicked or tapped, so it is called as the Upload button is pressed
+func openThemePicker() {
+	picker := js.Global().Get("document").Call("createElement", "input")
+	picker.Set("type", "file")
+	picker.Set("accept", themeFileExt+",application/json")
+	picker.Set("multiple", true)
+	var onChange, onCancel js.Func
+	onChange = js.FuncOf(func(js.Value, []js.Value) any {
+		onChange.Release()
+		onCancel.Release()
+		files := picker.Get("files")
+		for i := range files.Get("length").Int() {
+			readUploadedThemeFile(files.Call("item", i))
+		}
+		return nil
+	})
+	onCancel = js.FuncOf(func(js.Value, []js.Value) any {
+		onChange.Release()
+		onCancel.Release()
+		return nil
+	})
+	picker.Call("addEventListener", "change", onChange)
+	picker.Call("addEventListener", "cancel", onCancel)
+	picker.Call("click")
+}
+
+// readUploadedThemeFile reads the picked file's text and queues it to be imported
+func readUploadedThemeFile(file js.Value) {
+	name := file.Get("name").String()
+	var onRead, onError js.Func
+	onRead = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		onRead.Release()
+		onError.Release()
+		queueUploadedThemeFile(themeFileData{name: name, data: []byte(args[0].String())})
+		return nil
+	})
+	onError = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		onRead.Release()
+		onError.Release()
+		queueUploadedThemeFile(themeFileData{name: name, err: errors.New(args[0].Call("toString").String())})
+		return nil
+	})
+	file.Call("text").Call("then", onRead, onError)
+}
+
+func queueUploadedThemeFile(file themeFileData) {
+	uploadedThemeFilesM.Lock()
+	defer uploadedThemeFilesM.Unlock()
+	uploadedThemeFiles = append(uploadedThemeFiles, file)
+}
+
+// takeUploadedThemeFiles returns the theme files read since it was last called
+func takeUploadedThemeFiles() []themeFileData {
+	uploadedThemeFilesM.Lock()
+	defer uploadedThemeFilesM.Unlock()
+	files := uploadedThemeFiles
+	uploadedThemeFiles = nil
+	return files
+}
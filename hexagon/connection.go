@@ -0,0 +1,106 @@
+package hexagon
+
+import "math"
+
+// bufferWidthScale is the width of the gap left around a connection relative to the connection
+const bufferWidthScale = 4.0 / 3
+
+type ConnectionShape uint8
+
+const (
+	StraightConnection   ConnectionShape = iota // between opposite sides
+	LargeCurveConnection                        // between sides two apart, curving around the side between them
+	SmallCurveConnection                        // between adjacent sides, curving around the vertex between them
+)
+
+// ConnectionPath describes the line a connection takes through a hex, either a straight line or a circular arc
+// so it can be drawn by anything that draws lines and arcs
+type ConnectionPath struct {
+	Shape                ConnectionShape
+	From, To             Coordinate
+	Center               Coordinate // center of the arc, unused for straight connections
+	Radius               float64
+	StartAngle, EndAngle float64 // the arc goes clockwise from StartAngle to EndAngle
+}
+
+// ConnectionPath returns the path the connection takes through the hex
+func (this *Hex) ConnectionPath(connection Connection) ConnectionPath {
+	sideA := connection[0]
+	sideB := connection[1]
+	switch math.Abs(float64(sideA - sideB)) {
+	case 2:
+		centerSide := (sideA + sideB) / 2
+		return this.LargeCurvePath(math.Pi*2/3 + math.Pi*1/3*float64(centerSide))
+	case 4:
+		oppositeCenterSide := (sideA + sideB) / 2
+		return this.LargeCurvePath(-math.Pi*1/3 + math.Pi*1/3*float64(oppositeCenterSide))
+	case 1:
+		return this.SmallCurvePath(min(sideA, sideB))
+	case 5:
+		return this.SmallCurvePath(5)
+	default:
+		return this.StraightPath(connection)
+	}
+}
+
+// StraightPath returns a straight line between the connection's sides
+func (this *Hex) StraightPath(connection Connection) ConnectionPath {
+	sides := this.HexagonSideCoordinates()
+	return ConnectionPath{
+		Shape: StraightConnection,
+		From:  sides[connection[0]],
+		To:    sides[connection[1]],
+	}
+}
+
+// LargeCurvePath returns an arc around the side in the direction of angleToSide
+func (this *Hex) LargeCurvePath(angleToSide float64) ConnectionPath {
+	return newArcPath(
+		LargeCurveConnection,
+		Coordinate{
+			this.Center[0] - math.Cos(angleToSide)*this.SideRadius*2,
+			this.Center[1] - math.Sin(angleToSide)*this.SideRadius*2,
+		},
+		this.VertexRadius+this.SideRadius/2+float64(this.EdgeWidth),
+		angleToSide-math.Pi/6,
+		angleToSide+math.Pi/6,
+	)
+}
+
+// SmallCurvePath returns an arc around the vertex
+func (this *Hex) SmallCurvePath(vertex int) ConnectionPath {
+	adjustor := math.Pi / 3 * float64(vertex)
+	return newArcPath(
+		SmallCurveConnection,
+		this.VertexCoordinates()[vertex],
+		this.VertexRadius/2,
+		math.Pi/2+adjustor,
+		-math.Pi*5/6+adjustor,
+	)
+}
+
+func newArcPath(shape ConnectionShape, center Coordinate, radius, startAngle, endAngle float64) ConnectionPath {
+	return ConnectionPath{
+		Shape:      shape,
+		From:       Coordinate{getXCoordinateFromPolar(center[0], radius, startAngle), getYCoordinateFromPolar(center[1], radius, startAngle)},
+		To:         Coordinate{getXCoordinateFromPolar(center[0], radius, endAngle), getYCoordinateFromPolar(center[1], radius, endAngle)},
+		Center:     center,
+		Radius:     radius,
+		StartAngle: startAngle,
+		EndAngle:   endAngle,
+	}
+}
+
+// Sweep returns how far the arc turns clockwise, in radians
+func (this ConnectionPath) Sweep() float64 {
+	sweep := math.Mod(this.EndAngle-this.StartAngle, 2*math.Pi)
+	if sweep < 0 {
+		sweep += 2 * math.Pi
+	}
+	return sweep
+}
+
+// BufferWidth returns the width of the gap drawn under the hex's connections so crossing connections stand apart
+func (this *Hex) BufferWidth() float32 {
+	return this.ConnectionWidth * (1 + bufferWidthScale)
+}
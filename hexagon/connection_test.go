@@ -0,0 +1,48 @@
+package hexagon
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHex_ConnectionPath(t *testing.T) {
+	hex := NewHex(0, 0, 0, 0, HexVertexRadius, 2, 3)
+	tests := []struct {
+		name       string
+		connection Connection
+		wantShape  ConnectionShape
+		wantSweep  float64
+	}{
+		{"straight", Connection{0, 3}, StraightConnection, 0},
+		{"large curve", Connection{0, 2}, LargeCurveConnection, math.Pi / 3},
+		{"large curve across side 0", Connection{1, 5}, LargeCurveConnection, math.Pi / 3},
+		{"small curve", Connection{2, 3}, SmallCurveConnection, math.Pi * 2 / 3},
+		{"small curve across vertex 5", Connection{0, 5}, SmallCurveConnection, math.Pi * 2 / 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hex.ConnectionPath(tt.connection)
+			if got.Shape != tt.wantShape {
+				t.Errorf("ConnectionPath().Shape = %v, want %v", got.Shape, tt.wantShape)
+			}
+			if got.Shape != StraightConnection && math.Abs(got.Sweep()-tt.wantSweep) > 1e-9 {
+				t.Errorf("ConnectionPath().Sweep() = %v, want %v", got.Sweep(), tt.wantSweep)
+			}
+			reversed := hex.ConnectionPath(Connection{tt.connection[1], tt.connection[0]})
+			if reversed.Shape != got.Shape || reversed.Center != got.Center {
+				t.Errorf("ConnectionPath() of the reversed connection = %v, want %v", reversed, got)
+			}
+		})
+	}
+}
+
+func TestHex_StraightPath(t *testing.T) {
+	hex := NewHex(0, 0, 0, 0, HexVertexRadius, 2, 3)
+	sides := hex.HexagonSideCoordinates()
+	for side := range NumHexagonSides / 2 {
+		path := hex.StraightPath(Connection{side, side + 3})
+		if path.From != sides[side] || path.To != sides[side+3] {
+			t.Errorf("StraightPath() goes from %v to %v, want %v to %v", path.From, path.To, sides[side], sides[side+3])
+		}
+	}
+}
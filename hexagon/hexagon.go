@@ -1,20 +1,14 @@
 package hexagon
 
 import (
-	"bytes"
-	"log"
 	"math"
-
-	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
 const (
-	Scale               = 2
 	sqrt3               = 1.7320508075688772
-	HexVertexRadiusTest = 60 * Scale
+	HexVertexRadiusTest = 60
 	HexSideRadiusTest   = HexVertexRadiusTest * sqrt3 / 2
-	HexVertexRadius     = 30 * Scale // Distance from center of hexagon to vertex
+	HexVertexRadius     = 30 // Distance from center of hexagon to vertex
 	HexSideRadius       = HexVertexRadius * sqrt3 / 2
 	NumHexagonSides     = 6
 )
@@ -55,6 +49,16 @@ func NewHex(col, row int, originX, originY, hexVertexRadius float64, edgeWidth,
 	}
 }
 
+// Resize moves and resizes the hex in place as if it had been created by NewHex with the same arguments
+func (this *Hex) Resize(originX, originY, hexVertexRadius float64, edgeWidth, connectionWidth float32) {
+	resized := NewHex(this.Col, this.Row, originX, originY, hexVertexRadius, edgeWidth, connectionWidth)
+	this.VertexRadius = resized.VertexRadius
+	this.SideRadius = resized.SideRadius
+	this.EdgeWidth = resized.EdgeWidth
+	this.ConnectionWidth = resized.ConnectionWidth
+	this.Center = resized.Center
+}
+
 func (this *Hex) Empty() bool {
 	return len(this.Connections) == 0
 }
@@ -83,6 +87,26 @@ func (this *Hex) HexagonSideCoordinates() []Coordinate {
 	return sides
 }
 
+// BorderCorners returns the outer and inner corners of the hexagon's border, stroked EdgeWidth wide with mitered corners
+func (this *Hex) BorderCorners() (outer, inner []Coordinate) {
+	// the corners of a stroked regular hexagon are half the width over cos(30°) from the vertex
+	offset := float64(this.EdgeWidth) / math.Sqrt(3)
+	outer = make([]Coordinate, NumHexagonSides)
+	inner = make([]Coordinate, NumHexagonSides)
+	for i := 0; i < NumHexagonSides; i++ {
+		angle := math.Pi/3*float64(i) - math.Pi/6
+		outer[i] = Coordinate{
+			getXCoordinateFromPolar(this.Center[0], this.VertexRadius+offset, angle),
+			getYCoordinateFromPolar(this.Center[1], this.VertexRadius+offset, angle),
+		}
+		inner[i] = Coordinate{
+			getXCoordinateFromPolar(this.Center[0], this.VertexRadius-offset, angle),
+			getYCoordinateFromPolar(this.Center[1], this.VertexRadius-offset, angle),
+		}
+	}
+	return outer, inner
+}
+
 // PointInHexagon checks if a point is inside the hexagon
 func (this *Hex) PointInHexagon(px, py float64) bool {
 	buffer := .1 // prevents two hexagons being selected at once
@@ -122,6 +146,25 @@ func (this *Hex) Reset() {
 	this.Connections = nil
 }
 
+// Bounds returns the area covered by the hexes including their borders
+func Bounds(hexes []*Hex) (minX, minY, maxX, maxY float64) {
+	if len(hexes) == 0 {
+		return 0, 0, 0, 0
+	}
+	minX, minY = math.Inf(1), math.Inf(1)
+	maxX, maxY = math.Inf(-1), math.Inf(-1)
+	for _, hex := range hexes {
+		margin := float64(hex.EdgeWidth)
+		for _, vertex := range hex.VertexCoordinates() {
+			minX = min(minX, vertex[0]-margin)
+			minY = min(minY, vertex[1]-margin)
+			maxX = max(maxX, vertex[0]+margin)
+			maxY = max(maxY, vertex[1]+margin)
+		}
+	}
+	return minX, minY, maxX, maxY
+}
+
 func getXCoordinateFromPolar(centerX, radius, angle float64) float64 {
 	return centerX + radius*math.Cos(angle)
 }
@@ -158,18 +201,6 @@ type TextHexagon struct {
 	TextSize float64
 }
 
-func (this *TextHexagon) GetTextFace(textSize float64) text.Face {
-	fontFaceSource, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
-	if err != nil {
-		log.Fatal(err)
-	}
-	textFace := &text.GoTextFace{
-		Source: fontFaceSource,
-		Size:   textSize,
-	}
-	return textFace
-}
-
 func NewTextHexagon(col, row int, originX, originY, hexVertexRadius float64, edgeWidth, connectionWidth float32, str string, testSize float64) *TextHexagon {
 	return &TextHexagon{
 		Hex:      NewHex(col, row, originX, originY, hexVertexRadius, edgeWidth, connectionWidth),
@@ -177,3 +208,9 @@ func NewTextHexagon(col, row int, originX, originY, hexVertexRadius float64, edg
 		TextSize: testSize,
 	}
 }
+
+// Resize resizes the hexagon and scales its text by the same amount
+func (this *TextHexagon) Resize(originX, originY, hexVertexRadius float64, edgeWidth, connectionWidth float32) {
+	this.TextSize *= hexVertexRadius / this.VertexRadius
+	this.Hex.Resize(originX, originY, hexVertexRadius, edgeWidth, connectionWidth)
+}
@@ -0,0 +1,48 @@
+package hexagon
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHex_BorderCorners(t *testing.T) {
+	tests := []struct {
+		name      string
+		edgeWidth float32
+	}{
+		{"no border", 0},
+		{"board border", 2},
+		{"title border", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hex := NewHex(1, 2, 10, 20, HexVertexRadius, tt.edgeWidth, 3)
+			outer, inner := hex.BorderCorners()
+			vertices := hex.VertexCoordinates()
+			for i := range NumHexagonSides {
+				// the border is EdgeWidth wide measured across each side, centered on the side
+				next := (i + 1) % NumHexagonSides
+				outerSide := distanceToCenter(hex, midpoint(outer[i], outer[next]))
+				innerSide := distanceToCenter(hex, midpoint(inner[i], inner[next]))
+				if got := outerSide - innerSide; math.Abs(got-float64(tt.edgeWidth)) > 1e-9 {
+					t.Errorf("side %d is %v wide, want %v", i, got, tt.edgeWidth)
+				}
+				if got := (outerSide + innerSide) / 2; math.Abs(got-hex.SideRadius) > 1e-9 {
+					t.Errorf("side %d is centered %v from the center, want %v", i, got, hex.SideRadius)
+				}
+				// each corner is on the line from the center through its vertex
+				if got := midpoint(outer[i], inner[i]); math.Hypot(got[0]-vertices[i][0], got[1]-vertices[i][1]) > 1e-9 {
+					t.Errorf("corner %d is centered on %v, want %v", i, got, vertices[i])
+				}
+			}
+		})
+	}
+}
+
+func midpoint(a, b Coordinate) Coordinate {
+	return Coordinate{(a[0] + b[0]) / 2, (a[1] + b[1]) / 2}
+}
+
+func distanceToCenter(hex *Hex, point Coordinate) float64 {
+	return math.Hypot(point[0]-hex.Center[0], point[1]-hex.Center[1])
+}
@@ -0,0 +1,87 @@
+package hexagon
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	textPadding      = 0.85 // fraction of the hexagon text can fill
+	textShrink       = 0.9  // how much smaller each attempt to fit text is
+	minTextSizeScale = 0.25 // smallest text tried relative to TextSize
+)
+
+// TextMeasurer measures text in the font it will be drawn with
+type TextMeasurer interface {
+	Advance(str string, size float64) float64
+	LineHeight(size float64) float64
+}
+
+// FitText wraps the hexagon's text to fit inside it, shrinking the text from TextSize until it fits
+// the lines are returned joined by line breaks
+func (this *TextHexagon) FitText(measurer TextMeasurer) (str string, size float64) {
+	if strings.TrimSpace(this.Str) == "" {
+		return this.Str, this.TextSize
+	}
+	minSize := this.TextSize * minTextSizeScale
+	for size = this.TextSize; size > minSize; size *= textShrink {
+		if lines, ok := wrapInHexagon(this.Str, size, this.SideRadius*textPadding, measurer); ok {
+			return strings.Join(lines, "\n"), size
+		}
+	}
+	if lines, ok := wrapInHexagon(this.Str, minSize, this.SideRadius*textPadding, measurer); ok {
+		return strings.Join(lines, "\n"), minSize
+	}
+	// the text doesn't fit at any size so it overflows the hexagon at the smallest size
+	return this.Str, minSize
+}
+
+// wrapInHexagon wraps str into the fewest lines that fit a pointy topped hexagon with the given side radius
+func wrapInHexagon(str string, size, sideRadius float64, measurer TextMeasurer) (lines []string, ok bool) {
+	width := func(s string) float64 {
+		return measurer.Advance(s, size)
+	}
+	lineHeight := measurer.LineHeight(size)
+	// at most every word and blank line is on a line of its own
+	maxLines := len(Wrap(str, 0, width))
+	for numLines := 1; numLines <= maxLines; numLines++ {
+		// the sloped sides leave less room the taller the text is
+		halfHeight := float64(numLines) * lineHeight / 2
+		halfWidth := min(sideRadius, 2*sideRadius-halfHeight*math.Sqrt(3))
+		if halfWidth <= 0 {
+			return nil, false
+		}
+		lines = Wrap(str, halfWidth*2, width)
+		if len(lines) > numLines {
+			continue
+		}
+		for _, line := range lines {
+			if width(line) > halfWidth*2 {
+				return nil, false
+			}
+		}
+		return lines, true
+	}
+	return nil, false
+}
+
+// Wrap breaks str into lines no wider than maxWidth, keeping its own line breaks
+// a word wider than maxWidth is left on a line by itself
+func Wrap(str string, maxWidth float64, width func(string) float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(str, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line == "" {
+				line = word
+			} else if width(line+" "+word) <= maxWidth {
+				line += " " + word
+			} else {
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
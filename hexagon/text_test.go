@@ -0,0 +1,74 @@
+package hexagon
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testMeasurer makes every character half the text size wide
+type testMeasurer struct{}
+
+func (testMeasurer) Advance(str string, size float64) float64 {
+	return float64(len(str)) * size / 2
+}
+
+func (testMeasurer) LineHeight(size float64) float64 {
+	return size
+}
+
+func TestWrap(t *testing.T) {
+	// every character is one unit wide
+	width := func(s string) float64 {
+		return float64(len(s))
+	}
+	tests := []struct {
+		name     string
+		str      string
+		maxWidth float64
+		want     []string
+	}{
+		{"fits on one line", "How to Play", 11, []string{"How to Play"}},
+		{"wraps between words", "How to Play", 6, []string{"How to", "Play"}},
+		{"one word per line", "How to Play", 4, []string{"How", "to", "Play"}},
+		{"long word on its own line", "Restart now", 3, []string{"Restart", "now"}},
+		{"keeps line breaks", "Time\nAttack", 20, []string{"Time", "Attack"}},
+		{"collapses spaces", "  Time   Attack ", 20, []string{"Time Attack"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wrap(tt.str, tt.maxWidth, width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wrap() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextHexagon_FitText(t *testing.T) {
+	tests := []struct {
+		name      string
+		str       string
+		wantLines []string
+		wantSize  float64
+	}{
+		{"fits at full size", "Start", []string{"Start"}, 24},
+		{"wraps", "Time Attack", []string{"Time", "Attack"}, 24},
+		{"shrinks", "Supercalifragilistic", []string{"Supercalifragilistic"}, 24 * math.Pow(textShrink, 10)},
+		{"empty", "", []string{""}, 24},
+		{"keeps blank lines", "Time\n\nAttack", []string{"Time", "", "Attack"}, 24 * math.Pow(textShrink, 2)},
+		{"word too long at any size", "Supercalifragilisticexpialidocious", []string{"Supercalifragilisticexpialidocious"}, 24 * minTextSizeScale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hex := NewTextHexagon(0, 0, 0, 0, HexVertexRadiusTest, 1, 1, tt.str, 24)
+			str, size := hex.FitText(testMeasurer{})
+			if lines := strings.Split(str, "\n"); !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("FitText() = %q, want %q", lines, tt.wantLines)
+			}
+			if math.Abs(size-tt.wantSize) > 1e-9 {
+				t.Errorf("FitText() size = %v, want %v", size, tt.wantSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package hexagon
+
+// the text on the title screen's buttons, which the game finds them by
+const (
+	StartButton      = "Start"
+	TimeAttackButton = "Time Attack"
+	BlitzButton      = "Blitz"
+	TutorialButton   = "How to Play"
+	ThemesButton     = "Themes"
+	SettingsButton   = "Settings"
+)
+
+const titleFirstCol = -3
+
+// titleLayout is the text of each of the title screen's hexes by row, from column titleFirstCol, the empty ones are tiles
+var titleLayout = [2][7]string{
+	{"L", "H", "O", "E", "O", "X", "P"},
+	{StartButton, "", TimeAttackButton, ThemesButton, BlitzButton, SettingsButton, TutorialButton},
+}
+
+// NewTitleHexes returns the title screen's hexes at the title size, the letters of the title
+// over a row of buttons, the hexes without text are left without connections for the caller to fill
+func NewTitleHexes(edgeWidth, connectionWidth float32, letterSize, buttonSize float64) (titleHexes []*TextHexagon) {
+	for row, strs := range titleLayout {
+		textSize := letterSize
+		if row > 0 {
+			textSize = buttonSize
+		}
+		for i, str := range strs {
+			titleHexes = append(titleHexes, NewTextHexagon(titleFirstCol+i, row, 0, 0, HexVertexRadiusTest, edgeWidth, connectionWidth, str, textSize))
+		}
+	}
+	return titleHexes
+}
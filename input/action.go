@@ -0,0 +1,77 @@
+// Package input turns the physical controls, keys and gamepad buttons by name, into the actions the game responds to,
+// so the player can change the bindings and the scenes can be driven by scripted input
+package input
+
+// Action is something the player does, whatever control they do it with
+type Action uint8
+
+const (
+	Place    Action = iota // place the tile on the cursor, or press the focused control
+	Pause                  // pause or resume the game
+	Back                   // leave the screen
+	Next                   // focus the next control
+	Previous               // focus the previous control
+	CursorUp               // move the cursor a row or column
+	CursorDown
+	CursorLeft
+	CursorRight
+	HexUpRight // move the cursor across a side of the hex, clockwise from side 0
+	HexRight
+	HexDownRight
+	HexDownLeft
+	HexLeft
+	HexUpLeft
+	Fullscreen
+	SaveBoard // write the board to an SVG file
+	Click     // press what the mouse is on
+	Tap       // press what a finger touches
+	actionCount
+)
+
+// pointerActions start at Click, they act where the mouse or finger is so the player doesn't rebind them
+const pointerActions = Click
+
+// actionNames are how the actions are written in saved bindings
+var actionNames = [actionCount]string{
+	"place", "pause", "back", "next", "previous",
+	"cursorUp", "cursorDown", "cursorLeft", "cursorRight",
+	"hexUpRight", "hexRight", "hexDownRight", "hexDownLeft", "hexLeft", "hexUpLeft",
+	"fullscreen", "saveBoard", "click", "tap",
+}
+
+var actionLabels = [actionCount]string{
+	"Place", "Pause", "Back", "Next", "Previous",
+	"Cursor Up", "Cursor Down", "Cursor Left", "Cursor Right",
+	"Hex Up Right", "Hex Right", "Hex Down Right", "Hex Down Left", "Hex Left", "Hex Up Left",
+	"Fullscreen", "Save Board", "Click", "Tap",
+}
+
+// Actions returns every action the player can rebind in the order they are listed in the settings
+func Actions() []Action {
+	actions := make([]Action, pointerActions)
+	for i := range actions {
+		actions[i] = Action(i)
+	}
+	return actions
+}
+
+// HexSide returns the action that moves the cursor across the side of the hex
+func HexSide(side int) Action {
+	return HexUpRight + Action(side)
+}
+
+func (this Action) String() string {
+	if this >= actionCount {
+		return "Unknown"
+	}
+	return actionLabels[this]
+}
+
+func actionByName(name string) (Action, bool) {
+	for i, actionName := range actionNames {
+		if actionName == name {
+			return Action(i), true
+		}
+	}
+	return 0, false
+}
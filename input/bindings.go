@@ -0,0 +1,183 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MouseLeft is the control name of the left mouse button
+	MouseLeft = "MouseLeft"
+	// TouchScreen is the control name of a finger on the screen, it is held as long as the newest touch
+	TouchScreen = "TouchScreen"
+	// ShiftPrefix starts the name of a key pressed while Shift is held, like "Shift+Tab"
+	ShiftPrefix   = "Shift+"
+	gamepadPrefix = "Gamepad"
+	stickPrefix   = "Stick"
+)
+
+// stickControls are the control names of the left stick pointing at each side of the hex
+var stickControls = [6]string{"StickUpRight", "StickRight", "StickDownRight", "StickDownLeft", "StickLeft", "StickUpLeft"}
+
+// Bindings maps each action to the names of the controls that do it. Keys are named as ebiten names them,
+// gamepad buttons by their Xbox names after "Gamepad", like "GamepadA", and the D-pad as "GamepadUp" and so on.
+type Bindings map[Action][]string
+
+func DefaultBindings() Bindings {
+	return Bindings{
+		Place:        {"Enter", "NumpadEnter", "Space", "GamepadA"},
+		Pause:        {"Escape", "P", "GamepadB", "GamepadStart"},
+		Back:         {"Escape", "GamepadB"},
+		Next:         {"Tab", "GamepadRight", "GamepadDown"},
+		Previous:     {"Shift+Tab", "GamepadLeft", "GamepadUp"},
+		CursorUp:     {"ArrowUp", "GamepadUp"},
+		CursorDown:   {"ArrowDown", "GamepadDown"},
+		CursorLeft:   {"ArrowLeft", "GamepadLeft"},
+		CursorRight:  {"ArrowRight", "GamepadRight"},
+		HexUpRight:   {"W", stickControls[0]},
+		HexRight:     {"E", stickControls[1]},
+		HexDownRight: {"D", stickControls[2]},
+		HexDownLeft:  {"S", stickControls[3]},
+		HexLeft:      {"A", stickControls[4]},
+		HexUpLeft:    {"Q", stickControls[5]},
+		Fullscreen:   {"F11"},
+		SaveBoard:    {"F2"},
+		Click:        {MouseLeft},
+		Tap:          {TouchScreen},
+	}
+}
+
+// IsGamepad reports whether the control is on a gamepad
+func IsGamepad(control string) bool {
+	return strings.HasPrefix(control, gamepadPrefix) || strings.HasPrefix(control, stickPrefix)
+}
+
+// Label returns the control's name as it is shown to the player
+func Label(control string) string {
+	switch {
+	case strings.HasPrefix(control, stickPrefix):
+		return "Stick " + spaceWords(strings.TrimPrefix(control, stickPrefix))
+	case slices.Contains([]string{"GamepadUp", "GamepadDown", "GamepadLeft", "GamepadRight"}, control):
+		return "D-pad " + strings.TrimPrefix(control, gamepadPrefix)
+	case strings.HasPrefix(control, gamepadPrefix):
+		return strings.TrimPrefix(control, gamepadPrefix)
+	}
+	return control
+}
+
+// Keys returns the keyboard controls bound to the action
+func (this Bindings) Keys(action Action) []string {
+	return slices.DeleteFunc(slices.Clone(this[action]), IsGamepad)
+}
+
+// Gamepad returns the gamepad controls bound to the action
+func (this Bindings) Gamepad(action Action) []string {
+	return slices.DeleteFunc(slices.Clone(this[action]), func(control string) bool {
+		return !IsGamepad(control)
+	})
+}
+
+// Bind makes the control the only one on its device, the keyboard or a gamepad, that does the action.
+// The actions the control did before take the action's old control on that device in exchange, so pressing it
+// doesn't do two things at once. When the action has no control on that device to give, nothing changes and Bind returns false.
+func (this Bindings) Bind(action Action, control string) bool {
+	var old string
+	if i := slices.IndexFunc(this[action], func(bound string) bool {
+		return IsGamepad(bound) == IsGamepad(control)
+	}); i >= 0 {
+		old = this[action][i]
+	}
+	// a control the action already has keeps doing whatever else it did
+	var taken []Action
+	for other, controls := range this {
+		if other != action && slices.Contains(controls, control) && !slices.Contains(this[action], control) {
+			taken = append(taken, other)
+		}
+	}
+	if len(taken) > 0 && old == "" {
+		return false
+	}
+	for _, other := range taken {
+		i := slices.Index(this[other], control)
+		if slices.Contains(this[other], old) {
+			this[other] = slices.Delete(this[other], i, i+1)
+		} else {
+			this[other][i] = old
+		}
+	}
+	this[action] = slices.DeleteFunc(this[action], func(bound string) bool {
+		return IsGamepad(bound) == IsGamepad(control)
+	})
+	this[action] = append(this[action], control)
+	return true
+}
+
+// Clone returns a copy of the bindings that can be changed without changing these
+func (this Bindings) Clone() Bindings {
+	clone := make(Bindings, len(this))
+	for action, controls := range this {
+		clone[action] = slices.Clone(controls)
+	}
+	return clone
+}
+
+// bound reports whether any action is bound to the control
+func (this Bindings) bound(control string) bool {
+	for _, controls := range this {
+		if slices.Contains(controls, control) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON writes the bindings as an object of action names to lists of control names,
+// the pointer actions are left out since they can't be rebound
+func (this Bindings) MarshalJSON() ([]byte, error) {
+	named := make(map[string][]string, len(this))
+	for action, controls := range this {
+		if action < pointerActions {
+			named[actionNames[action]] = controls
+		}
+	}
+	return json.Marshal(named)
+}
+
+// ParseBindings reads bindings written by MarshalJSON, the actions they leave out keep their default bindings.
+// Actions it doesn't know, from another version of the game, are skipped and returned so they can be reported.
+// The pointer actions always keep their default bindings.
+func ParseBindings(data []byte) (bindings Bindings, unknown []string, err error) {
+	var named map[string][]string
+	if err := json.Unmarshal(data, &named); err != nil {
+		return nil, nil, fmt.Errorf("invalid bindings: %w", err)
+	}
+	bindings = DefaultBindings()
+	for name, controls := range named {
+		action, ok := actionByName(name)
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		if action >= pointerActions {
+			continue
+		}
+		bindings[action] = controls
+	}
+	slices.Sort(unknown)
+	return bindings, unknown, nil
+}
+
+// spaceWords puts a space before each word after the first in a name like "UpRight"
+func spaceWords(name string) string {
+	var words strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			words.WriteRune(' ')
+		}
+		words.WriteRune(r)
+	}
+	return words.String()
+}
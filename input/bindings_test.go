@@ -0,0 +1,128 @@
+package input
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBindings_Bind(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  Action
+		control string
+		want    Bindings // the actions whose controls changed
+		wantOk  bool
+	}{
+		{name: "key", action: Place, control: "K", want: Bindings{Place: {"GamepadA", "K"}}, wantOk: true},
+		{name: "gamepad button", action: Place, control: "GamepadX", want: Bindings{Place: {"Enter", "NumpadEnter", "Space", "GamepadX"}}, wantOk: true},
+		{
+			name:    "key bound to another action",
+			action:  Place,
+			control: "Tab",
+			want:    Bindings{Place: {"GamepadA", "Tab"}, Next: {"Enter", "GamepadRight", "GamepadDown"}},
+			wantOk:  true,
+		},
+		{
+			name:    "stick bound to another action",
+			action:  Place,
+			control: "StickLeft",
+			want:    Bindings{Place: {"Enter", "NumpadEnter", "Space", "StickLeft"}, HexLeft: {"A", "GamepadA"}},
+			wantOk:  true,
+		},
+		{
+			name:    "bound to two other actions, one already has the old control",
+			action:  CursorUp,
+			control: "GamepadLeft",
+			want: Bindings{
+				CursorUp:   {"ArrowUp", "GamepadLeft"},
+				CursorLeft: {"ArrowLeft", "GamepadUp"},
+				Previous:   {"Shift+Tab", "GamepadUp"},
+			},
+			wantOk: true,
+		},
+		{name: "shared with another action already", action: Back, control: "Escape", want: Bindings{Back: {"GamepadB", "Escape"}}, wantOk: true},
+		{name: "nothing to swap", action: Fullscreen, control: "GamepadA", want: Bindings{}, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindings := DefaultBindings()
+			if ok := bindings.Bind(tt.action, tt.control); ok != tt.wantOk {
+				t.Errorf("Bind() = %v, want %v", ok, tt.wantOk)
+			}
+			for action, want := range DefaultBindings() {
+				if controls, ok := tt.want[action]; ok {
+					want = controls
+				}
+				if got := bindings[action]; !slices.Equal(got, want) {
+					t.Errorf("bindings[%v] = %v, want %v", action, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseBindings(t *testing.T) {
+	changed := DefaultBindings()
+	changed.Bind(Pause, "K")
+	changed.Bind(HexLeft, "GamepadLB")
+	// a pointer action changed in memory isn't saved
+	changed[Click] = []string{"K"}
+	data, err := changed.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed[Click] = DefaultBindings()[Click]
+	placeK := DefaultBindings()
+	placeK[Place] = []string{"K"}
+	tests := []struct {
+		name        string
+		data        string
+		want        Bindings
+		wantUnknown []string
+		wantErr     bool
+	}{
+		{name: "round trip", data: string(data), want: changed},
+		{name: "left out actions keep their defaults", data: `{"place": ["K"]}`, want: placeK},
+		{name: "unknown action", data: `{"jump": ["Space"]}`, want: DefaultBindings(), wantUnknown: []string{"jump"}},
+		{name: "unknown and known actions", data: `{"place": ["K"], "jump": ["Space"], "dash": ["D"]}`, want: placeK, wantUnknown: []string{"dash", "jump"}},
+		{name: "pointer actions keep their defaults", data: `{"click": [], "tap": ["Space"]}`, want: DefaultBindings()},
+		{name: "not json", data: `place`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unknown, err := ParseBindings([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBindings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(unknown, tt.wantUnknown) {
+				t.Errorf("ParseBindings() unknown = %v, want %v", unknown, tt.wantUnknown)
+			}
+			for action := range actionCount {
+				if !tt.wantErr && !slices.Equal(got[action], tt.want[action]) {
+					t.Errorf("ParseBindings()[%v] = %v, want %v", action, got[action], tt.want[action])
+				}
+			}
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		control string
+		want    string
+	}{
+		{control: "Enter", want: "Enter"},
+		{control: "Shift+Tab", want: "Shift+Tab"},
+		{control: "GamepadA", want: "A"},
+		{control: "GamepadUp", want: "D-pad Up"},
+		{control: "StickUpRight", want: "Stick Up Right"},
+		{control: "StickRight", want: "Stick Right"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.control, func(t *testing.T) {
+			if got := Label(tt.control); got != tt.want {
+				t.Errorf("Label() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
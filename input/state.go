@@ -0,0 +1,200 @@
+package input
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	repeatDelayTicks    = 15 // how long a control is held before the action starts repeating
+	repeatIntervalTicks = 5
+	stickDeadZone       = 0.5 // how far the stick is pushed before it points at a side
+)
+
+// Touch is a finger on the screen
+type Touch struct {
+	ID    int
+	X, Y  int
+	Ticks int // how long it has been down, 1 on the tick it touched
+}
+
+// Frame is what the controls are doing on one tick, read from the devices or scripted by a test
+type Frame struct {
+	Held           map[string]int // how long each held control has been held, 1 on the tick it was pressed
+	MouseX, MouseY int
+	Touches        []Touch
+	StickX, StickY float64 // the left stick, y is down like the screen
+}
+
+// State turns the frames into actions with the bindings and remembers what it needs between ticks
+type State struct {
+	Bindings     Bindings
+	frame        Frame
+	held         map[string]int
+	mouseMoved   bool
+	stickSide    int
+	stickTicks   int // how long the stick has pointed at stickSide
+	gamepadInUse bool
+}
+
+func NewState(bindings Bindings) *State {
+	return &State{Bindings: bindings, held: map[string]int{}}
+}
+
+// Update takes the frame for this tick
+func (this *State) Update(frame Frame) {
+	this.mouseMoved = frame.MouseX != this.frame.MouseX || frame.MouseY != this.frame.MouseY || frame.Held[MouseLeft] == 1
+	this.frame = frame
+
+	clear(this.held)
+	for control, ticks := range frame.Held {
+		this.held[control] = ticks
+	}
+	side, ok := stickSide(frame.StickX, frame.StickY)
+	switch {
+	case !ok:
+		this.stickTicks = 0
+	case side != this.stickSide:
+		this.stickSide, this.stickTicks = side, 1
+	default:
+		this.stickTicks++
+	}
+	if this.stickTicks > 0 {
+		this.held[stickControls[this.stickSide]] = this.stickTicks
+	}
+	for _, touch := range frame.Touches {
+		if this.held[TouchScreen] == 0 || touch.Ticks < this.held[TouchScreen] {
+			this.held[TouchScreen] = touch.Ticks
+		}
+	}
+
+	if this.mouseMoved || this.tapped() {
+		this.gamepadInUse = false
+	}
+	for control, ticks := range this.held {
+		if ticks != 1 {
+			continue
+		}
+		if !IsGamepad(control) {
+			this.gamepadInUse = false
+			break
+		}
+		this.gamepadInUse = true
+	}
+}
+
+// JustPressed reports whether a control bound to the action was pressed this tick
+func (this *State) JustPressed(action Action) bool {
+	return slices.ContainsFunc(this.Bindings[action], func(control string) bool {
+		return this.ticks(control) == 1
+	})
+}
+
+// Repeated reports whether a control bound to the action was pressed this tick, or has been held long enough to repeat
+func (this *State) Repeated(action Action) bool {
+	return slices.ContainsFunc(this.Bindings[action], func(control string) bool {
+		return repeatTick(this.ticks(control))
+	})
+}
+
+// Mouse returns where the mouse is
+func (this *State) Mouse() (x, y int) {
+	return this.frame.MouseX, this.frame.MouseY
+}
+
+// MouseMoved reports whether the mouse moved or clicked this tick, which hands control back to it
+func (this *State) MouseMoved() bool {
+	return this.mouseMoved
+}
+
+// Tap returns the first touch that started this tick, when it does the Tap action
+func (this *State) Tap() (Touch, bool) {
+	if !this.JustPressed(Tap) {
+		return Touch{}, false
+	}
+	for _, touch := range this.frame.Touches {
+		if touch.Ticks == 1 {
+			return touch, true
+		}
+	}
+	return Touch{}, false
+}
+
+// Touch returns the touch with the id if it is still down
+func (this *State) Touch(id int) (Touch, bool) {
+	for _, touch := range this.frame.Touches {
+		if touch.ID == id {
+			return touch, true
+		}
+	}
+	return Touch{}, false
+}
+
+// GamepadInUse reports whether a gamepad was used more recently than the mouse, keyboard or touch
+func (this *State) GamepadInUse() bool {
+	return this.gamepadInUse
+}
+
+// JustPressedControl returns a key or gamepad control pressed this tick to bind to an action,
+// Shift on its own isn't one but a key pressed with Shift is
+func (this *State) JustPressedControl() (string, bool) {
+	var pressed []string
+	for control, ticks := range this.held {
+		if ticks == 1 && !isPointer(control) && !isShiftKey(control) {
+			pressed = append(pressed, control)
+		}
+	}
+	if len(pressed) == 0 {
+		return "", false
+	}
+	slices.Sort(pressed)
+	for _, control := range pressed {
+		if strings.HasPrefix(control, ShiftPrefix) {
+			return control, true
+		}
+	}
+	return pressed[0], true
+}
+
+// ticks returns how long the control has been held, a key held with Shift isn't held itself when its Shift+ version is bound
+func (this *State) ticks(control string) int {
+	if !strings.HasPrefix(control, ShiftPrefix) && this.held[ShiftPrefix+control] > 0 && this.Bindings.bound(ShiftPrefix+control) {
+		return 0
+	}
+	return this.held[control]
+}
+
+func (this *State) tapped() bool {
+	return this.held[TouchScreen] == 1
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func repeatTick(heldTicks int) bool {
+	if heldTicks == 1 {
+		return true
+	}
+	return heldTicks >= repeatDelayTicks && (heldTicks-repeatDelayTicks)%repeatIntervalTicks == 0
+}
+
+// stickSide returns the side of the hex closest to the direction the stick points, y is down like the screen.
+// The sides are 60 degrees apart clockwise from the top right.
+func stickSide(x, y float64) (side int, ok bool) {
+	if math.Hypot(x, y) < stickDeadZone {
+		return 0, false
+	}
+	// 0 degrees points right at side 1
+	sixths := int(math.Round(math.Atan2(y, x) * 180 / math.Pi / 60))
+	return ((sixths+1)%6 + 6) % 6, true
+}
+
+// isShiftKey reports whether the control is one of the Shift keys on its own
+func isShiftKey(control string) bool {
+	return strings.HasPrefix(control, "Shift") && !strings.HasPrefix(control, ShiftPrefix)
+}
+
+// isPointer reports whether the control presses where the mouse or a finger is
+func isPointer(control string) bool {
+	return control == MouseLeft || control == TouchScreen
+}
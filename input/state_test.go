@@ -0,0 +1,199 @@
+package input
+
+import (
+	"testing"
+)
+
+// play updates a new state with default bindings through the frames
+func play(frames ...Frame) *State {
+	state := NewState(DefaultBindings())
+	for _, frame := range frames {
+		state.Update(frame)
+	}
+	return state
+}
+
+func held(controls ...string) Frame {
+	frame := Frame{Held: map[string]int{}}
+	for _, control := range controls {
+		frame.Held[control] = 1
+	}
+	return frame
+}
+
+func TestState_JustPressed(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []Frame
+		action Action
+		want   bool
+	}{
+		{name: "key just pressed", frames: []Frame{held("Enter")}, action: Place, want: true},
+		{name: "gamepad button just pressed", frames: []Frame{held("GamepadA")}, action: Place, want: true},
+		{name: "held since last tick", frames: []Frame{{Held: map[string]int{"Enter": 2}}}, action: Place, want: false},
+		{name: "unbound key", frames: []Frame{held("Z")}, action: Place, want: false},
+		{name: "nothing pressed", frames: []Frame{{}}, action: Place, want: false},
+		{name: "tab", frames: []Frame{held("Tab")}, action: Next, want: true},
+		{name: "shift tab isn't tab", frames: []Frame{held("Tab", "Shift+Tab")}, action: Next, want: false},
+		{name: "shift tab", frames: []Frame{held("Tab", "Shift+Tab")}, action: Previous, want: true},
+		{name: "shift with an unbound shift version", frames: []Frame{held("Enter", "Shift+Enter")}, action: Place, want: true},
+		{name: "stick pushed", frames: []Frame{{StickX: 1}}, action: HexRight, want: true},
+		{name: "stick held", frames: []Frame{{StickX: 1}, {StickX: 1}}, action: HexRight, want: false},
+		{name: "stick pushed to another side", frames: []Frame{{StickX: 1}, {StickX: -1}}, action: HexLeft, want: true},
+		{name: "stick pushed again", frames: []Frame{{StickX: 1}, {}, {StickX: 1}}, action: HexRight, want: true},
+		{name: "mouse clicked", frames: []Frame{held(MouseLeft)}, action: Click, want: true},
+		{name: "mouse held", frames: []Frame{{Held: map[string]int{MouseLeft: 2}}}, action: Click, want: false},
+		{name: "screen tapped", frames: []Frame{{Touches: []Touch{{ID: 1, Ticks: 1}}}}, action: Tap, want: true},
+		{name: "tapped with another finger down", frames: []Frame{{Touches: []Touch{{ID: 1, Ticks: 5}, {ID: 2, Ticks: 1}}}}, action: Tap, want: true},
+		{name: "screen touched", frames: []Frame{{Touches: []Touch{{ID: 1, Ticks: 2}}}}, action: Tap, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := play(tt.frames...).JustPressed(tt.action); got != tt.want {
+				t.Errorf("JustPressed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestState_Repeated(t *testing.T) {
+	tests := []struct {
+		name      string
+		heldTicks int
+		want      bool
+	}{
+		{name: "just pressed", heldTicks: 1, want: true},
+		{name: "waiting to repeat", heldTicks: 2, want: false},
+		{name: "repeating", heldTicks: repeatDelayTicks, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := play(Frame{Held: map[string]int{"ArrowUp": tt.heldTicks}})
+			if got := state.Repeated(CursorUp); got != tt.want {
+				t.Errorf("Repeated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestState_GamepadInUse(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []Frame
+		want   bool
+	}{
+		{name: "nothing used", frames: []Frame{{}}, want: false},
+		{name: "button pressed", frames: []Frame{held("GamepadA")}, want: true},
+		{name: "stick pushed", frames: []Frame{{StickY: -1}}, want: true},
+		{name: "still in use", frames: []Frame{held("GamepadA"), {}}, want: true},
+		{name: "key pressed after", frames: []Frame{held("GamepadA"), held("Enter")}, want: false},
+		{name: "mouse moved after", frames: []Frame{held("GamepadA"), {MouseX: 10}}, want: false},
+		{name: "tapped after", frames: []Frame{held("GamepadA"), {Touches: []Touch{{Ticks: 1}}}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := play(tt.frames...).GamepadInUse(); got != tt.want {
+				t.Errorf("GamepadInUse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestState_Tap(t *testing.T) {
+	tests := []struct {
+		name    string
+		touches []Touch
+		wantID  int
+		wantOk  bool
+	}{
+		{name: "no touches", wantOk: false},
+		{name: "touch held", touches: []Touch{{ID: 1, Ticks: 3}}, wantOk: false},
+		{name: "tapped", touches: []Touch{{ID: 1, Ticks: 3}, {ID: 2, Ticks: 1}}, wantID: 2, wantOk: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			touch, ok := play(Frame{Touches: tt.touches}).Tap()
+			if ok != tt.wantOk || touch.ID != tt.wantID {
+				t.Errorf("Tap() = %v, %v, want id %v, %v", touch, ok, tt.wantID, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestState_JustPressedControl(t *testing.T) {
+	tests := []struct {
+		name   string
+		frame  Frame
+		want   string
+		wantOk bool
+	}{
+		{name: "nothing pressed", frame: Frame{}, wantOk: false},
+		{name: "key", frame: held("K"), want: "K", wantOk: true},
+		{name: "gamepad button", frame: held("GamepadX"), want: "GamepadX", wantOk: true},
+		{name: "stick", frame: Frame{StickX: -1}, want: "StickLeft", wantOk: true},
+		{name: "shift on its own", frame: held("Shift", "ShiftLeft"), wantOk: false},
+		{name: "key with shift", frame: held("Shift", "ShiftLeft", "K", "Shift+K"), want: "Shift+K", wantOk: true},
+		{name: "mouse click", frame: held(MouseLeft), wantOk: false},
+		{name: "tap", frame: Frame{Touches: []Touch{{ID: 1, Ticks: 1}}}, wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := play(tt.frame).JustPressedControl()
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("JustPressedControl() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_repeatTick(t *testing.T) {
+	tests := []struct {
+		name      string
+		heldTicks int
+		want      bool
+	}{
+		{name: "not held", heldTicks: 0, want: false},
+		{name: "just pressed", heldTicks: 1, want: true},
+		{name: "waiting to repeat", heldTicks: repeatDelayTicks - 1, want: false},
+		{name: "first repeat", heldTicks: repeatDelayTicks, want: true},
+		{name: "between repeats", heldTicks: repeatDelayTicks + 1, want: false},
+		{name: "second repeat", heldTicks: repeatDelayTicks + repeatIntervalTicks, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repeatTick(tt.heldTicks); got != tt.want {
+				t.Errorf("repeatTick() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_stickSide(t *testing.T) {
+	type args struct {
+		x, y float64
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantSide int
+		wantOk   bool
+	}{
+		{name: "resting", args: args{x: 0.1, y: -0.2}, wantOk: false},
+		{name: "up right", args: args{x: 0.5, y: -0.87}, wantSide: 0, wantOk: true},
+		{name: "right", args: args{x: 1, y: 0}, wantSide: 1, wantOk: true},
+		{name: "down right", args: args{x: 0.5, y: 0.87}, wantSide: 2, wantOk: true},
+		{name: "down left", args: args{x: -0.5, y: 0.87}, wantSide: 3, wantOk: true},
+		{name: "left", args: args{x: -1, y: 0}, wantSide: 4, wantOk: true},
+		{name: "up left", args: args{x: -0.5, y: -0.87}, wantSide: 5, wantOk: true},
+		{name: "straight up leans right", args: args{x: 0.01, y: -1}, wantSide: 0, wantOk: true},
+		{name: "nearly right", args: args{x: 0.9, y: -0.2}, wantSide: 1, wantOk: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			side, ok := stickSide(tt.args.x, tt.args.y)
+			if ok != tt.wantOk || (ok && side != tt.wantSide) {
+				t.Errorf("stickSide() = %v, %v, want %v, %v", side, ok, tt.wantSide, tt.wantOk)
+			}
+		})
+	}
+}
@@ -1,16 +1,45 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/tliddle1/hexloop/font"
 	"github.com/tliddle1/hexloop/game"
 )
 
 func main() {
+	animationOptions := game.DefaultAnimationOptions()
+	flag.BoolVar(&animationOptions.Enabled, "animations", animationOptions.Enabled, "animate placed tiles and completed loops")
+	fontPath := flag.String("font", "", "TrueType or OpenType font to draw text with instead of the bundled font")
+	svgPath := flag.String("svg", "", "SVG file to write the board to when Save Board, F2 by default, is pressed, for docs and bug reports")
+	flag.Parse()
+
+	if *fontPath != "" {
+		if err := useFont(*fontPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	game := game.NewGame()
+	game.SetAnimationOptions(animationOptions)
+	game.SetSVGPath(*svgPath)
 	ebiten.SetWindowSize(game.ScreenWidth, game.ScreenHeight)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatal(err)
 	}
 }
+
+func useFont(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := font.Register(path, data); err != nil {
+		return err
+	}
+	return font.Use(path)
+}
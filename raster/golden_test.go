@@ -0,0 +1,245 @@
+package raster
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images in testdata/golden with the current rendering")
+
+const (
+	// pixelTolerance is how far a channel can differ before a pixel counts as different, allowing for antialiasing
+	pixelTolerance = 16
+	// maxDifferentPixels is the fraction of pixels that can differ before an image no longer matches
+	maxDifferentPixels = 0.002
+)
+
+var (
+	goldenThemes = []struct {
+		name  string
+		theme *color2.Theme
+	}{
+		{"bee", color2.NewBeeTheme()},
+		{"blue", color2.NewBlueTheme()},
+	}
+	// tiles are all the ways to connect the six sides of a hex in pairs
+	tiles = [][]hexagon.Connection{
+		{{0, 1}, {2, 3}, {4, 5}},
+		{{0, 1}, {2, 4}, {3, 5}},
+		{{0, 1}, {2, 5}, {3, 4}},
+		{{0, 2}, {1, 3}, {4, 5}},
+		{{0, 2}, {1, 4}, {3, 5}},
+		{{0, 2}, {1, 5}, {3, 4}},
+		{{0, 3}, {1, 2}, {4, 5}},
+		{{0, 3}, {1, 4}, {2, 5}},
+		{{0, 3}, {1, 5}, {2, 4}},
+		{{0, 4}, {1, 2}, {3, 5}},
+		{{0, 4}, {1, 3}, {2, 5}},
+		{{0, 4}, {1, 5}, {2, 3}},
+		{{0, 5}, {1, 2}, {3, 4}},
+		{{0, 5}, {1, 3}, {2, 4}},
+		{{0, 5}, {1, 4}, {2, 3}},
+	}
+)
+
+func TestGolden_tiles(t *testing.T) {
+	for _, tile := range tiles {
+		hex := hexagon.NewHex(0, 0, 0, 0, hexagon.HexVertexRadiusTest, 4, 6)
+		hex.Connections = tile
+		for _, theme := range goldenThemes {
+			name := fmt.Sprintf("tile_%d%d_%d%d_%d%d_%s", tile[0][0], tile[0][1], tile[1][0], tile[1][1], tile[2][0], tile[2][1], theme.name)
+			t.Run(name, func(t *testing.T) {
+				checkGolden(t, name, Board([]*hexagon.Hex{hex}, nil, theme.theme))
+			})
+		}
+	}
+}
+
+func TestGolden_titleBoard(t *testing.T) {
+	for _, theme := range goldenThemes {
+		name := "title_" + theme.name
+		t.Run(name, func(t *testing.T) {
+			checkGolden(t, name, titleBoard(theme.theme))
+		})
+	}
+}
+
+func TestGolden_boards(t *testing.T) {
+	boards := []struct {
+		name  string
+		board func() ([]*hexagon.Hex, []hexagon.Loop)
+	}{
+		{"empty", emptyBoard},
+		{"full", fullBoard},
+		{"small_loop", smallLoopBoard},
+	}
+	for _, board := range boards {
+		for _, theme := range goldenThemes {
+			name := "board_" + board.name + "_" + theme.name
+			t.Run(name, func(t *testing.T) {
+				hexes, loops := board.board()
+				checkGolden(t, name, Board(hexes, loops, theme.theme))
+			})
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// newGameBoard returns an empty board the size of the game's
+func newGameBoard() []*hexagon.Hex {
+	var hexes []*hexagon.Hex
+	for row := range 5 {
+		for col := range 18 {
+			hexes = append(hexes, hexagon.NewHex(col, row, 0, 0, hexagon.HexVertexRadius, 2, 3))
+		}
+	}
+	return hexes
+}
+
+func emptyBoard() ([]*hexagon.Hex, []hexagon.Loop) {
+	return newGameBoard(), nil
+}
+
+func fullBoard() ([]*hexagon.Hex, []hexagon.Loop) {
+	hexes := newGameBoard()
+	random := rand.New(rand.NewSource(1))
+	for _, hex := range hexes {
+		hex.Connections = tiles[random.Intn(len(tiles))]
+	}
+	return hexes, nil
+}
+
+// smallLoopBoard has the smallest possible loop, three small curves around a vertex, in the middle of other tiles
+func smallLoopBoard() ([]*hexagon.Hex, []hexagon.Loop) {
+	hexes, _ := fullBoard()
+	find := func(col, row int) *hexagon.Hex {
+		for _, hex := range hexes {
+			if hex.Col == col && hex.Row == row {
+				return hex
+			}
+		}
+		panic("no such hex")
+	}
+	a, b, c := find(7, 1), find(9, 1), find(8, 2)
+	a.Connections = []hexagon.Connection{{1, 2}, {0, 3}, {4, 5}}
+	b.Connections = []hexagon.Connection{{3, 4}, {0, 1}, {2, 5}}
+	c.Connections = []hexagon.Connection{{0, 5}, {1, 4}, {2, 3}}
+	loop := hexagon.Loop{
+		{Hex: a, Connection: hexagon.Connection{1, 2}},
+		{Hex: c, Connection: hexagon.Connection{0, 5}},
+		{Hex: b, Connection: hexagon.Connection{3, 4}},
+	}
+	return hexes, []hexagon.Loop{loop}
+}
+
+// titleBoard renders the game's title screen hexes with fixed tiles in place of the random ones
+func titleBoard(theme *color2.Theme) *image.RGBA {
+	titleHexes := hexagon.NewTitleHexes(8, 12, 72, 24)
+	var hexes []*hexagon.Hex
+	for i, hex := range titleHexes {
+		if hex.Str == "" {
+			hex.Connections = tiles[i%len(tiles)]
+		}
+		hexes = append(hexes, hex.Hex)
+	}
+	minX, minY, maxX, maxY := hexagon.Bounds(hexes)
+	for _, hex := range hexes {
+		hex.Center[0] -= minX
+		hex.Center[1] -= minY
+	}
+	img := NewImage(int(maxX-minX), int(maxY-minY), theme.BackgroundColor)
+	for _, hex := range titleHexes {
+		TextHexagon(img, hex, theme.HexBorderColor, theme.ConnectionColor)
+	}
+	for _, hex := range titleHexes {
+		HexagonConnections(img, hex.Hex, theme.ConnectionColor, theme.BackgroundColor)
+	}
+	return img
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// checkGolden compares img to testdata/golden/name.png, or rewrites the golden image when -update is set
+func checkGolden(t *testing.T, name string, img *image.RGBA) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".png")
+	if *update {
+		if err := writePNGFile(path, img); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := readPNGFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test ./raster -update to create it)", err)
+	}
+	if !img.Bounds().Eq(want.Bounds()) {
+		t.Fatalf("image is %v, golden image is %v", img.Bounds(), want.Bounds())
+	}
+	if different := differentPixels(img, want); different > maxDifferentPixels {
+		actual := filepath.Join(t.TempDir(), name+".png")
+		if err := writePNGFile(actual, img); err != nil {
+			t.Fatal(err)
+		}
+		t.Errorf("%.2f%% of pixels differ from %s, the image was written to %s", different*100, path, actual)
+	}
+}
+
+// differentPixels returns the fraction of pixels with a channel that differs by more than pixelTolerance
+func differentPixels(a, b image.Image) float64 {
+	bounds := a.Bounds()
+	different := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r1, g1, b1, a1 := a.At(x, y).RGBA()
+			r2, g2, b2, a2 := b.At(x, y).RGBA()
+			if channelDiff(r1, r2) > pixelTolerance || channelDiff(g1, g2) > pixelTolerance ||
+				channelDiff(b1, b2) > pixelTolerance || channelDiff(a1, a2) > pixelTolerance {
+				different++
+			}
+		}
+	}
+	return float64(different) / float64(bounds.Dx()*bounds.Dy())
+}
+
+// channelDiff returns the difference between two 16 bit channels in 8 bit units
+func channelDiff(a, b uint32) uint32 {
+	if a > b {
+		return (a - b) >> 8
+	}
+	return (b - a) >> 8
+}
+
+func readPNGFile(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNGFile(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
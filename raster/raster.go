@@ -0,0 +1,167 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"slices"
+
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/hexagon"
+	"golang.org/x/image/vector"
+)
+
+// arcSegmentAngle is the most an arc turns between the points it is flattened into
+const arcSegmentAngle = math.Pi / 48
+
+// NewImage returns an image of the given size filled with clr
+func NewImage(width, height int, clr color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(clr), image.Point{}, draw.Src)
+	return img
+}
+
+// Board renders the board the same way the game draws it into an image sized to fit the hexes
+func Board(hexes []*hexagon.Hex, loops []hexagon.Loop, theme *color2.Theme) *image.RGBA {
+	minX, minY, maxX, maxY := hexagon.Bounds(hexes)
+	img := NewImage(int(math.Ceil(maxX-minX)), int(math.Ceil(maxY-minY)), theme.BackgroundColor)
+	shifted := translate(hexes, loops, -minX, -minY)
+	for _, hex := range shifted.hexes {
+		Hexagon(img, hex, theme.HexBorderColor)
+	}
+	for _, hex := range shifted.hexes {
+		HexagonConnections(img, hex, theme.ConnectionColor, theme.BackgroundColor)
+	}
+	Loops(img, shifted.loops, theme.CompletedLoopColor, theme.BackgroundColor)
+	return img
+}
+
+// WritePNG renders the board and encodes it as a PNG
+func WritePNG(w io.Writer, hexes []*hexagon.Hex, loops []hexagon.Loop, theme *color2.Theme) error {
+	return png.Encode(w, Board(hexes, loops, theme))
+}
+
+// Hexagon draws the hexagon's border with mitered corners
+func Hexagon(dst *image.RGBA, hex *hexagon.Hex, borderColor color.RGBA) {
+	outer, inner := hex.BorderCorners()
+	rasterizer := newRasterizer(dst)
+	addPolygon(rasterizer, outer)
+	// the inner hexagon is added the other way round so it cuts a hole in the outer one
+	slices.Reverse(inner)
+	addPolygon(rasterizer, inner)
+	fill(dst, rasterizer, borderColor)
+}
+
+// HexagonConnections draws each of the hex's connections
+func HexagonConnections(dst *image.RGBA, hex *hexagon.Hex, connectionColor, backgroundColor color.RGBA) {
+	for _, connection := range hex.Connections {
+		HexagonConnection(dst, hex, connection, connectionColor, backgroundColor)
+	}
+}
+
+// HexagonConnection draws the connection over a wider stroke of the background color so crossing connections stand apart
+func HexagonConnection(dst *image.RGBA, hex *hexagon.Hex, connection hexagon.Connection, connectionColor, backgroundColor color.RGBA) {
+	path := hex.ConnectionPath(connection)
+	strokeConnectionPath(dst, path, float64(hex.BufferWidth()), backgroundColor)
+	strokeConnectionPath(dst, path, float64(hex.ConnectionWidth), connectionColor)
+}
+
+// Loops draws the completed loops over the board
+func Loops(dst *image.RGBA, loops []hexagon.Loop, completedLoopColor, backgroundColor color.RGBA) {
+	for _, loop := range loops {
+		for _, hexConnection := range loop {
+			HexagonConnection(dst, hexConnection.Hex, hexConnection.Connection, completedLoopColor, backgroundColor)
+		}
+	}
+}
+
+// strokeConnectionPath fills the outline of the path stroked with butt ends
+func strokeConnectionPath(dst *image.RGBA, path hexagon.ConnectionPath, strokeWidth float64, clr color.RGBA) {
+	rasterizer := newRasterizer(dst)
+	if path.Shape == hexagon.StraightConnection {
+		dx, dy := path.To[0]-path.From[0], path.To[1]-path.From[1]
+		length := math.Hypot(dx, dy)
+		// normal to the line, half the stroke width long
+		nx, ny := -dy/length*strokeWidth/2, dx/length*strokeWidth/2
+		addPolygon(rasterizer, []hexagon.Coordinate{
+			{path.From[0] + nx, path.From[1] + ny},
+			{path.To[0] + nx, path.To[1] + ny},
+			{path.To[0] - nx, path.To[1] - ny},
+			{path.From[0] - nx, path.From[1] - ny},
+		})
+	} else {
+		sweep := path.Sweep()
+		steps := int(math.Ceil(sweep / arcSegmentAngle))
+		outline := make([]hexagon.Coordinate, 0, 2*(steps+1))
+		// out along the outer edge of the arc and back along the inner edge
+		for _, radius := range []float64{path.Radius + strokeWidth/2, path.Radius - strokeWidth/2} {
+			for i := range steps + 1 {
+				step := i
+				if radius < path.Radius {
+					step = steps - i
+				}
+				angle := path.StartAngle + sweep*float64(step)/float64(steps)
+				outline = append(outline, hexagon.Coordinate{
+					path.Center[0] + radius*math.Cos(angle),
+					path.Center[1] + radius*math.Sin(angle),
+				})
+			}
+		}
+		addPolygon(rasterizer, outline)
+	}
+	fill(dst, rasterizer, clr)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func newRasterizer(dst *image.RGBA) *vector.Rasterizer {
+	size := dst.Bounds().Size()
+	return vector.NewRasterizer(size.X, size.Y)
+}
+
+func addPolygon(rasterizer *vector.Rasterizer, points []hexagon.Coordinate) {
+	rasterizer.MoveTo(float32(points[0][0]), float32(points[0][1]))
+	for _, point := range points[1:] {
+		rasterizer.LineTo(float32(point[0]), float32(point[1]))
+	}
+	rasterizer.ClosePath()
+}
+
+func fill(dst *image.RGBA, rasterizer *vector.Rasterizer, clr color.RGBA) {
+	rasterizer.DrawOp = draw.Over
+	rasterizer.Draw(dst, dst.Bounds(), image.NewUniform(clr), image.Point{})
+}
+
+type board struct {
+	hexes []*hexagon.Hex
+	loops []hexagon.Loop
+}
+
+// translate returns copies of the hexes and loops moved by (dx, dy), leaving the originals untouched
+func translate(hexes []*hexagon.Hex, loops []hexagon.Loop, dx, dy float64) board {
+	moved := make(map[*hexagon.Hex]*hexagon.Hex, len(hexes))
+	var result board
+	move := func(hex *hexagon.Hex) *hexagon.Hex {
+		if copied, ok := moved[hex]; ok {
+			return copied
+		}
+		copied := *hex
+		copied.Center = hexagon.Coordinate{hex.Center[0] + dx, hex.Center[1] + dy}
+		moved[hex] = &copied
+		return &copied
+	}
+	for _, hex := range hexes {
+		result.hexes = append(result.hexes, move(hex))
+	}
+	for _, loop := range loops {
+		movedLoop := make(hexagon.Loop, len(loop))
+		for i, hexConnection := range loop {
+			movedLoop[i] = hexagon.HexConnection{Hex: move(hexConnection.Hex), Connection: hexConnection.Connection}
+		}
+		result.loops = append(result.loops, movedLoop)
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package raster
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+func TestBoard(t *testing.T) {
+	theme := color2.NewBeeTheme()
+	hex := hexagon.NewHex(0, 0, 100, 100, hexagon.HexVertexRadius*2, 4, 6)
+	hex.Connections = []hexagon.Connection{{1, 4}}
+	img := Board([]*hexagon.Hex{hex}, nil, theme)
+
+	minX, minY, _, _ := hexagon.Bounds([]*hexagon.Hex{hex})
+	centerX, centerY := int(hex.Center[0]-minX), int(hex.Center[1]-minY)
+	vertex := hex.VertexCoordinates()[1]
+	tests := []struct {
+		name string
+		x, y int
+		want color.RGBA
+	}{
+		{"connection through the center", centerX, centerY, theme.ConnectionColor},
+		{"background inside the hex", centerX, centerY + int(hex.SideRadius/2), theme.BackgroundColor},
+		{"border at a vertex", int(vertex[0] - minX), int(vertex[1] - minY), theme.HexBorderColor},
+		{"background in the corner", 0, 0, theme.BackgroundColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := img.RGBAAt(tt.x, tt.y); got != tt.want {
+				t.Errorf("pixel at (%d, %d) = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoard_doesNotMoveHexes(t *testing.T) {
+	hex := hexagon.NewHex(0, 0, 100, 100, hexagon.HexVertexRadius, 2, 3)
+	Board([]*hexagon.Hex{hex}, nil, color2.NewBeeTheme())
+	if hex.Center != (hexagon.Coordinate{100, 100}) {
+		t.Errorf("Board() moved the hex to %v", hex.Center)
+	}
+}
+
+func TestTextHexagon(t *testing.T) {
+	theme := color2.NewBeeTheme()
+	hex := hexagon.NewTextHexagon(0, 0, 100, 100, hexagon.HexVertexRadiusTest, 4, 6, "How to Play", 24)
+	img := NewImage(200, 200, theme.BackgroundColor)
+	TextHexagon(img, hex, theme.HexBorderColor, theme.ConnectionColor)
+	textPixels := 0
+	for y := 50; y < 150; y++ {
+		for x := 50; x < 150; x++ {
+			if img.RGBAAt(x, y) != theme.BackgroundColor {
+				textPixels++
+			}
+		}
+	}
+	if textPixels == 0 {
+		t.Errorf("TextHexagon() drew no text")
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	hex := hexagon.NewHex(0, 0, 0, 0, hexagon.HexVertexRadius, 2, 3)
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, []*hexagon.Hex{hex}, nil, color2.NewBlueTheme()); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("WritePNG() wrote an invalid PNG: %v", err)
+	}
+	if img.Bounds().Empty() {
+		t.Errorf("WritePNG() wrote an empty image")
+	}
+}
@@ -0,0 +1,88 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
+	"github.com/tliddle1/hexloop/hexagon"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// maxCachedFaces bounds the face cache like the font package does for the game
+const maxCachedFaces = 256
+
+var (
+	mplus     *opentype.Font
+	mplusErr  error
+	mplusOnce sync.Once
+	faces     = map[float64]font.Face{}
+	facesM    sync.Mutex
+)
+
+// face returns the bundled MPlus font at the given size
+func face(size float64) font.Face {
+	mplusOnce.Do(func() {
+		mplus, mplusErr = opentype.Parse(fonts.MPlus1pRegular_ttf)
+	})
+	if mplusErr != nil {
+		panic(mplusErr)
+	}
+	facesM.Lock()
+	defer facesM.Unlock()
+	if f, ok := faces[size]; ok {
+		return f
+	}
+	if len(faces) >= maxCachedFaces {
+		clear(faces)
+	}
+	f, err := opentype.NewFace(mplus, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingNone})
+	if err != nil {
+		panic(err)
+	}
+	faces[size] = f
+	return f
+}
+
+// faceMeasurer measures text with the bundled font
+type faceMeasurer struct{}
+
+func (faceMeasurer) Advance(str string, size float64) float64 {
+	return fixedToFloat(font.MeasureString(face(size), str))
+}
+
+func (faceMeasurer) LineHeight(size float64) float64 {
+	return fixedToFloat(face(size).Metrics().Height)
+}
+
+// TextHexagon draws the hexagon with its text wrapped and centered inside it
+func TextHexagon(dst *image.RGBA, hex *hexagon.TextHexagon, borderColor, textColor color.RGBA) {
+	Hexagon(dst, hex.Hex, borderColor)
+	str, size := hex.FitText(faceMeasurer{})
+	if strings.TrimSpace(str) == "" {
+		return
+	}
+	f := face(size)
+	lines := strings.Split(str, "\n")
+	lineHeight := faceMeasurer{}.LineHeight(size)
+	top := hex.Center[1] - float64(len(lines))*lineHeight/2
+	drawer := font.Drawer{Dst: dst, Src: image.NewUniform(textColor), Face: f}
+	for i, line := range lines {
+		x := hex.Center[0] - faceMeasurer{}.Advance(line, size)/2
+		baseline := top + float64(i)*lineHeight + fixedToFloat(f.Metrics().Ascent)
+		drawer.Dot = fixed.Point26_6{X: floatToFixed(x), Y: floatToFixed(baseline)}
+		drawer.DrawString(line)
+	}
+}
+
+func fixedToFloat(v fixed.Int26_6) float64 {
+	return float64(v) / 64
+}
+
+func floatToFixed(v float64) fixed.Int26_6 {
+	return fixed.Int26_6(v * 64)
+}
@@ -0,0 +1,8 @@
+// Package storage keeps small settings between runs,
+// in the browser's local storage on the web and in the user's config directory on the desktop
+package storage
+
+import "errors"
+
+// ErrNotFound is returned by Load when nothing has been saved under the key
+var ErrNotFound = errors.New("storage: not found")
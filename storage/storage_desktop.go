@@ -0,0 +1,73 @@
+//go:build !js
+
+package storage
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// configDir is replaced in tests so they don't touch the real config directory
+var configDir = os.UserConfigDir
+
+// Load returns the value saved under key
+func Load(key string) (string, error) {
+	path, err := keyPath(key)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Save saves value under key, replacing what was saved before
+func Save(key, value string) error {
+	path, err := keyPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(value), 0o644)
+}
+
+// List returns the keys saved under dir, each as dir/name
+func List(dir string) ([]string, error) {
+	path, err := keyPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			keys = append(keys, dir+"/"+entry.Name())
+		}
+	}
+	return keys, nil
+}
+
+// keyPath returns the file the key is saved in, one file per key in the game's config directory
+// keys with slashes are saved in subdirectories
+func keyPath(key string) (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "hexloop", key), nil
+}
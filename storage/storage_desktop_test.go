@@ -0,0 +1,81 @@
+//go:build !js
+
+package storage
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func useTempConfigDir(t *testing.T) {
+	dir := t.TempDir()
+	original := configDir
+	configDir = func() (string, error) { return dir, nil }
+	t.Cleanup(func() { configDir = original })
+}
+
+func TestSaveLoad(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string // saved in order under the same key
+		want   string
+	}{
+		{name: "one value", values: []string{"Blue"}, want: "Blue"},
+		{name: "replaced value", values: []string{"Blue", "Bee"}, want: "Bee"},
+		{name: "empty value", values: []string{""}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempConfigDir(t)
+			for _, value := range tt.values {
+				if err := Save("theme", value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := Load("theme")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Load() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_notFound(t *testing.T) {
+	useTempConfigDir(t)
+	if _, err := Load("theme"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Load() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name  string
+		saved []string
+		want  []string
+	}{
+		{name: "nothing saved", saved: nil, want: nil},
+		{name: "only keys in the directory", saved: []string{"theme", "themes/mint.json", "themes/dusk.json"}, want: []string{"themes/dusk.json", "themes/mint.json"}},
+		{name: "not in subdirectories", saved: []string{"themes/old/mint.json", "themes/dusk.json"}, want: []string{"themes/dusk.json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempConfigDir(t)
+			for _, key := range tt.saved {
+				if err := Save(key, "{}"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := List("themes")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+//go:build js
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"syscall/js"
+)
+
+// keyPrefix keeps the game's keys apart from anything else served from the same origin
+const keyPrefix = "hexloop."
+
+var errUnavailable = errors.New("storage: local storage is unavailable")
+
+// Load returns the value saved under key
+func Load(key string) (string, error) {
+	localStorage, err := getLocalStorage()
+	if err != nil {
+		return "", err
+	}
+	value := localStorage.Call("getItem", keyPrefix+key)
+	if value.IsNull() || value.IsUndefined() {
+		return "", ErrNotFound
+	}
+	return value.String(), nil
+}
+
+// Save saves value under key, replacing what was saved before
+func Save(key, value string) (err error) {
+	localStorage, err := getLocalStorage()
+	if err != nil {
+		return err
+	}
+	// setItem throws when the storage is full or disabled
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("storage: saving %q: %v", key, r)
+		}
+	}()
+	localStorage.Call("setItem", keyPrefix+key, value)
+	return nil
+}
+
+// List returns the keys saved under dir, each as dir/name
+func List(dir string) ([]string, error) {
+	localStorage, err := getLocalStorage()
+	if err != nil {
+		return nil, err
+	}
+	prefix := keyPrefix + dir + "/"
+	var keys []string
+	for i := range localStorage.Get("length").Int() {
+		key := localStorage.Call("key", i).String()
+		if name, ok := strings.CutPrefix(key, prefix); ok && !strings.Contains(name, "/") {
+			keys = append(keys, strings.TrimPrefix(key, keyPrefix))
+		}
+	}
+	// sorted like the files listed on the desktop
+	slices.Sort(keys)
+	return keys, nil
+}
+
+func getLocalStorage() (localStorage js.Value, err error) {
+	// reading localStorage throws when the browser blocks it
+	defer func() {
+		if r := recover(); r != nil {
+			err = errUnavailable
+		}
+	}()
+	localStorage = js.Global().Get("localStorage")
+	if !localStorage.Truthy() {
+		return js.Value{}, errUnavailable
+	}
+	return localStorage, nil
+}
@@ -0,0 +1,108 @@
+package svg
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+// Write writes the board as an SVG document drawn the same way as the game draws it:
+// hex borders, then each hex's connections, then the completed loops on top
+// the document is sized to fit the hexes
+func Write(w io.Writer, hexes []*hexagon.Hex, loops []hexagon.Loop, theme *color2.Theme) error {
+	var buf bytes.Buffer
+	minX, minY, maxX, maxY := hexagon.Bounds(hexes)
+	width, height := maxX-minX, maxY-minY
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%s %s %s %s" width="%s" height="%s">`+"\n",
+		number(minX), number(minY), number(width), number(height), number(width), number(height))
+	fmt.Fprintf(&buf, `<rect x="%s" y="%s" width="%s" height="%s" %s/>`+"\n",
+		number(minX), number(minY), number(width), number(height), paint("fill", theme.BackgroundColor))
+
+	buf.WriteString(`<g fill="none" stroke-linejoin="miter">` + "\n")
+	for _, hex := range hexes {
+		writeHexagon(&buf, hex, theme.HexBorderColor)
+	}
+	buf.WriteString("</g>\n")
+
+	buf.WriteString(`<g fill="none" stroke-linecap="butt">` + "\n")
+	for _, hex := range hexes {
+		for _, connection := range hex.Connections {
+			writeConnection(&buf, hex, connection, theme.ConnectionColor, theme.BackgroundColor)
+		}
+	}
+	for _, loop := range loops {
+		for _, hexConnection := range loop {
+			writeConnection(&buf, hexConnection.Hex, hexConnection.Connection, theme.CompletedLoopColor, theme.BackgroundColor)
+		}
+	}
+	buf.WriteString("</g>\n</svg>\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeHexagon(buf *bytes.Buffer, hex *hexagon.Hex, borderColor color.RGBA) {
+	buf.WriteString(`<polygon points="`)
+	for i, vertex := range hex.VertexCoordinates() {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(number(vertex[0]) + "," + number(vertex[1]))
+	}
+	fmt.Fprintf(buf, `" %s stroke-width="%s"/>`+"\n", paint("stroke", borderColor), number(float64(hex.EdgeWidth)))
+}
+
+// writeConnection writes the connection over a wider stroke of the background color so crossing connections stand apart
+func writeConnection(buf *bytes.Buffer, hex *hexagon.Hex, connection hexagon.Connection, connectionColor, backgroundColor color.RGBA) {
+	d := pathData(hex.ConnectionPath(connection))
+	fmt.Fprintf(buf, `<path d="%s" %s stroke-width="%s"/>`+"\n", d, paint("stroke", backgroundColor), number(float64(hex.BufferWidth())))
+	fmt.Fprintf(buf, `<path d="%s" %s stroke-width="%s"/>`+"\n", d, paint("stroke", connectionColor), number(float64(hex.ConnectionWidth)))
+}
+
+// pathData returns the path's SVG path data, arcs are clockwise which is a sweep flag of 1 with y pointing down
+func pathData(path hexagon.ConnectionPath) string {
+	from := "M" + number(path.From[0]) + " " + number(path.From[1])
+	to := number(path.To[0]) + " " + number(path.To[1])
+	if path.Shape == hexagon.StraightConnection {
+		return from + " L" + to
+	}
+	largeArc := 0
+	if path.Sweep() > math.Pi {
+		largeArc = 1
+	}
+	radius := number(path.Radius)
+	return from + " A" + radius + " " + radius + " 0 " + strconv.Itoa(largeArc) + " 1 " + to
+}
+
+// paint returns the attributes that set attr to clr, un-premultiplying translucent colors
+func paint(attr string, clr color.RGBA) string {
+	if clr.A == 0 {
+		return attr + `="none"`
+	}
+	r, g, b := clr.R, clr.G, clr.B
+	if clr.A < 255 {
+		r = uint8(uint16(r) * 255 / uint16(clr.A))
+		g = uint8(uint16(g) * 255 / uint16(clr.A))
+		b = uint8(uint16(b) * 255 / uint16(clr.A))
+	}
+	attrs := fmt.Sprintf(`%s="#%02x%02x%02x"`, attr, r, g, b)
+	if clr.A < 255 {
+		attrs += fmt.Sprintf(` %s-opacity="%s"`, attr, number(float64(clr.A)/255))
+	}
+	return attrs
+}
+
+// number formats v with at most two decimal places
+func number(v float64) string {
+	v = math.Round(v*100) / 100
+	if v == 0 {
+		v = 0 // no negative zero
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
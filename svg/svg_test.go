@@ -0,0 +1,99 @@
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"io"
+	"testing"
+
+	color2 "github.com/tliddle1/hexloop/color"
+	"github.com/tliddle1/hexloop/hexagon"
+)
+
+func newTestBoard() (hexes []*hexagon.Hex, loops []hexagon.Loop) {
+	hexA := hexagon.NewHex(0, 0, 60, 60, hexagon.HexVertexRadius, 2, 3)
+	hexA.Connections = []hexagon.Connection{{0, 3}, {1, 5}, {2, 4}}
+	hexB := hexagon.NewHex(1, 0, 60, 60, hexagon.HexVertexRadius, 2, 3)
+	hexB.Connections = []hexagon.Connection{{0, 1}, {2, 3}, {4, 5}}
+	loops = []hexagon.Loop{{
+		{Hex: hexB, Connection: hexagon.Connection{0, 1}},
+		{Hex: hexB, Connection: hexagon.Connection{2, 3}},
+	}}
+	return []*hexagon.Hex{hexA, hexB}, loops
+}
+
+func TestWrite(t *testing.T) {
+	hexes, loops := newTestBoard()
+	var buf bytes.Buffer
+	if err := Write(&buf, hexes, loops, color2.NewDefaultTheme()); err != nil {
+		t.Fatal(err)
+	}
+
+	elements := map[string]int{}
+	decoder := xml.NewDecoder(&buf)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Write() is not valid XML: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			elements[start.Name.Local]++
+		}
+	}
+
+	want := map[string]int{
+		"svg":     1,
+		"rect":    1,
+		"g":       2,
+		"polygon": len(hexes),
+		// a background and a foreground stroke for each connection and each loop segment
+		"path": 2 * (len(hexes[0].Connections) + len(hexes[1].Connections) + len(loops[0])),
+	}
+	for name, count := range want {
+		if elements[name] != count {
+			t.Errorf("Write() has %d <%s> elements, want %d", elements[name], name, count)
+		}
+	}
+}
+
+func Test_pathData(t *testing.T) {
+	hex := hexagon.NewHex(0, 0, 0, 0, 10, 0, 1)
+	tests := []struct {
+		name       string
+		connection hexagon.Connection
+		want       string
+	}{
+		{"straight", hexagon.Connection{1, 4}, "M8.66 0 L-8.66 0"},
+		{"small curve", hexagon.Connection{0, 1}, "M8.66 0 A5 5 0 0 1 4.33 -7.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pathData(hex.ConnectionPath(tt.connection)); got != tt.want {
+				t.Errorf("pathData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_paint(t *testing.T) {
+	tests := []struct {
+		name string
+		clr  color.RGBA
+		want string
+	}{
+		{"opaque", color.RGBA{R: 133, G: 77, B: 13, A: 255}, `stroke="#854d0d"`},
+		{"translucent", color.RGBA{R: 128, G: 0, B: 0, A: 128}, `stroke="#ff0000" stroke-opacity="0.5"`},
+		{"transparent", color.RGBA{}, `stroke="none"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := paint("stroke", tt.clr); got != tt.want {
+				t.Errorf("paint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
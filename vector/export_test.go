@@ -30,3 +30,15 @@ func IsPointCloseToSegment(p, p0, p1 Point, allow float32) bool {
 		y: p1.Y,
 	}, allow)
 }
+
+func (p *Path) SubpathPoints() [][]Point {
+	var subpaths [][]Point
+	for _, subpath := range p.ensureSubpaths() {
+		var points []Point
+		for _, pt := range subpath.points {
+			points = append(points, Point{X: pt.x, Y: pt.y})
+		}
+		subpaths = append(subpaths, points)
+	}
+	return subpaths
+}
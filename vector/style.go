@@ -0,0 +1,163 @@
+package vector
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// StrokeStyle is the full set of options for the stroking helpers that take one,
+// the zero value is a butt-ended, miter-joined solid stroke with no antialiasing
+type StrokeStyle struct {
+	StrokeOptions
+
+	// Dash alternates the lengths of dashes and gaps along the stroke, starting with a dash.
+	// An odd number of lengths is repeated to make an even number, like SVG's stroke-dasharray.
+	// The stroke is solid when Dash is empty.
+	Dash []float32
+
+	// DashOffset is how far into the dash pattern the stroke starts.
+	DashOffset float32
+
+	// Gradient, when not nil, colors the stroke instead of its solid color.
+	Gradient *Gradient
+
+	// AntiAlias reports whether the stroke is antialiased.
+	AntiAlias bool
+}
+
+// Gradient blends between two colors along the line from (X0, Y0) to (X1, Y1), coloring each vertex by its
+// projection onto the line. Vertices before the start are From and vertices past the end are To.
+type Gradient struct {
+	X0, Y0, X1, Y1 float32
+	From, To       color.Color
+}
+
+// StrokePath strokes the path in the specified color with the style.
+//
+// clr has to be a solid (non-transparent) color.
+func StrokePath(dst *ebiten.Image, path *Path, clr color.Color, style *StrokeStyle) {
+	if len(style.Dash) > 0 {
+		path = path.Dashed(style.Dash, style.DashOffset)
+	}
+
+	useCachedVerticesAndIndices(func(vs []ebiten.Vertex, is []uint16) ([]ebiten.Vertex, []uint16) {
+		vs, is = path.AppendVerticesAndIndicesForStroke(vs, is, &style.StrokeOptions)
+		if style.Gradient == nil {
+			drawVerticesForUtil(dst, vs, is, clr, style.AntiAlias)
+			return vs, is
+		}
+		style.Gradient.colorVertices(vs)
+		DrawVertices(dst, vs, is, style.AntiAlias)
+		return vs, is
+	})
+}
+
+// StrokeLineWithStyle strokes a line (x0, y0)-(x1, y1) in the specified color with the style.
+//
+// clr has to be a solid (non-transparent) color.
+func StrokeLineWithStyle(dst *ebiten.Image, x0, y0, x1, y1 float32, clr color.Color, style *StrokeStyle) {
+	var path Path
+	path.MoveTo(x0, y0)
+	path.LineTo(x1, y1)
+	StrokePath(dst, &path, clr, style)
+}
+
+// StrokePartialCircleWithStyle strokes the arc of the circle at (cx, cy) with radius r
+// clockwise from startAngle to endAngle in the specified color with the style.
+//
+// clr has to be a solid (non-transparent) color.
+func StrokePartialCircleWithStyle(dst *ebiten.Image, cx, cy, r, startAngle, endAngle float32, clr color.Color, style *StrokeStyle) {
+	var path Path
+	path.Arc(cx, cy, r, startAngle, endAngle, Clockwise)
+	StrokePath(dst, &path, clr, style)
+}
+
+// Dashed returns a path made of the dashes of this path's pattern, each dash is its own subpath.
+// pattern is as StrokeStyle's Dash, a pattern with a negative length or no length at all leaves the path solid.
+func (p *Path) Dashed(pattern []float32, offset float32) *Path {
+	var total float32
+	for _, length := range pattern {
+		if length < 0 {
+			return p
+		}
+		total += length
+	}
+	if total <= 0 {
+		return p
+	}
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float32{}, pattern...), pattern...)
+		total *= 2
+	}
+
+	var dashed Path
+	for _, subpath := range p.ensureSubpaths() {
+		if subpath.pointCount() < 2 {
+			continue
+		}
+
+		// find where the offset lands in the pattern, every subpath starts from there
+		i := 0
+		start := float32(math.Mod(float64(offset), float64(total)))
+		if start < 0 {
+			start += total
+		}
+		for start >= pattern[i] {
+			start -= pattern[i]
+			i = (i + 1) % len(pattern)
+		}
+		remaining := pattern[i] - start
+		on := i%2 == 0
+		if on {
+			dashed.MoveTo(subpath.points[0].x, subpath.points[0].y)
+		}
+
+		for j := 1; j < subpath.pointCount(); j++ {
+			p0, p1 := subpath.points[j-1], subpath.points[j]
+			dx, dy := p1.x-p0.x, p1.y-p0.y
+			length := float32(math.Hypot(float64(dx), float64(dy)))
+			var done float32
+			for length-done > remaining {
+				done += remaining
+				x, y := p0.x+dx*done/length, p0.y+dy*done/length
+				if on {
+					dashed.LineTo(x, y)
+				} else {
+					dashed.MoveTo(x, y)
+				}
+				on = !on
+				i = (i + 1) % len(pattern)
+				remaining = pattern[i]
+			}
+			remaining -= length - done
+			if on {
+				dashed.LineTo(p1.x, p1.y)
+			}
+		}
+	}
+	return &dashed
+}
+
+func (g *Gradient) colorVertices(vs []ebiten.Vertex) {
+	r0, g0, b0, a0 := g.From.RGBA()
+	r1, g1, b1, a1 := g.To.RGBA()
+	dx, dy := g.X1-g.X0, g.Y1-g.Y0
+	lengthSquared := dx*dx + dy*dy
+	for i := range vs {
+		var t float32
+		if lengthSquared > 0 {
+			t = ((vs[i].DstX-g.X0)*dx + (vs[i].DstY-g.Y0)*dy) / lengthSquared
+			t = min(max(t, 0), 1)
+		}
+		vs[i].ColorR = lerpChannel(r0, r1, t)
+		vs[i].ColorG = lerpChannel(g0, g1, t)
+		vs[i].ColorB = lerpChannel(b0, b1, t)
+		vs[i].ColorA = lerpChannel(a0, a1, t)
+	}
+}
+
+func lerpChannel(from, to uint32, t float32) float32 {
+	return (float32(from) + (float32(to)-float32(from))*t) / 0xffff
+}
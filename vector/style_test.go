@@ -0,0 +1,88 @@
+package vector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPath_Dashed(t *testing.T) {
+	line := func() *Path {
+		var path Path
+		path.MoveTo(0, 0)
+		path.LineTo(30, 0)
+		return &path
+	}
+	corner := func() *Path {
+		var path Path
+		path.MoveTo(0, 0)
+		path.LineTo(10, 0)
+		path.LineTo(10, 10)
+		return &path
+	}
+	testCases := []struct {
+		name    string
+		path    *Path
+		pattern []float32
+		offset  float32
+		want    [][]Point
+	}{
+		{
+			name:    "solid without a pattern",
+			path:    line(),
+			pattern: nil,
+			want:    [][]Point{{{0, 0}, {30, 0}}},
+		},
+		{
+			name:    "solid with a negative length",
+			path:    line(),
+			pattern: []float32{10, -5},
+			want:    [][]Point{{{0, 0}, {30, 0}}},
+		},
+		{
+			name:    "dashes and gaps",
+			path:    line(),
+			pattern: []float32{10, 5},
+			want:    [][]Point{{{0, 0}, {10, 0}}, {{15, 0}, {25, 0}}},
+		},
+		{
+			name:    "odd pattern repeats",
+			path:    line(),
+			pattern: []float32{10},
+			want:    [][]Point{{{0, 0}, {10, 0}}, {{20, 0}, {30, 0}}},
+		},
+		{
+			name:    "offset into a dash",
+			path:    line(),
+			pattern: []float32{10, 5},
+			offset:  5,
+			want:    [][]Point{{{0, 0}, {5, 0}}, {{10, 0}, {20, 0}}, {{25, 0}, {30, 0}}},
+		},
+		{
+			name:    "offset into a gap",
+			path:    line(),
+			pattern: []float32{10, 5},
+			offset:  12,
+			want:    [][]Point{{{3, 0}, {13, 0}}, {{18, 0}, {28, 0}}},
+		},
+		{
+			name:    "negative offset",
+			path:    line(),
+			pattern: []float32{10, 5},
+			offset:  -3,
+			want:    [][]Point{{{3, 0}, {13, 0}}, {{18, 0}, {28, 0}}},
+		},
+		{
+			name:    "dash turns the corner",
+			path:    corner(),
+			pattern: []float32{15, 2},
+			want:    [][]Point{{{0, 0}, {10, 0}, {10, 5}}, {{10, 7}, {10, 10}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.path.Dashed(tc.pattern, tc.offset).SubpathPoints(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got: %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
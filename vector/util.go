@@ -67,21 +67,28 @@ func drawVerticesForUtil(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, clr
 	dst.DrawTriangles(vs, is, whiteSubImage, op)
 }
 
+// DrawVertices draws triangles filled with the color of their vertices.
+//
+// The vertices' colors have to be solid (non-transparent) and are treated as premultiplied alpha.
+func DrawVertices(dst *ebiten.Image, vs []ebiten.Vertex, is []uint16, antialias bool) {
+	for i := range vs {
+		vs[i].SrcX = 1
+		vs[i].SrcY = 1
+	}
+
+	op := &ebiten.DrawTrianglesOptions{}
+	op.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+	op.AntiAlias = antialias
+	dst.DrawTriangles(vs, is, whiteSubImage, op)
+}
+
 // StrokeLine strokes a line (x0, y0)-(x1, y1) with the specified width and color.
 //
 // clr has be to be a solid (non-transparent) color.
 func StrokeLine(dst *ebiten.Image, x0, y0, x1, y1 float32, strokeWidth float32, clr color.Color, antialias bool) {
-	var path Path
-	path.MoveTo(x0, y0)
-	path.LineTo(x1, y1)
-	strokeOp := &StrokeOptions{}
-	strokeOp.Width = strokeWidth
-
-	useCachedVerticesAndIndices(func(vs []ebiten.Vertex, is []uint16) ([]ebiten.Vertex, []uint16) {
-		vs, is = path.AppendVerticesAndIndicesForStroke(vs, is, strokeOp)
-		drawVerticesForUtil(dst, vs, is, clr, antialias)
-		return vs, is
-	})
+	style := &StrokeStyle{AntiAlias: antialias}
+	style.Width = strokeWidth
+	StrokeLineWithStyle(dst, x0, y0, x1, y1, clr, style)
 }
 
 // DrawFilledRect fills a rectangle with the specified width and color.
@@ -155,16 +162,7 @@ func StrokeCircle(dst *ebiten.Image, cx, cy, r float32, strokeWidth float32, clr
 //
 // clr has to be a solid (non-transparent) color.
 func StrokePartialCircle(dst *ebiten.Image, cx, cy, r, startAngle, totalAngle float32, strokeWidth float32, clr color.Color, antialias bool) {
-	var path Path
-	path.Arc(cx, cy, r, startAngle, totalAngle, Clockwise)
-	//path.Close()
-
-	strokeOp := &StrokeOptions{}
-	strokeOp.Width = strokeWidth
-
-	useCachedVerticesAndIndices(func(vs []ebiten.Vertex, is []uint16) ([]ebiten.Vertex, []uint16) {
-		vs, is = path.AppendVerticesAndIndicesForStroke(vs, is, strokeOp)
-		drawVerticesForUtil(dst, vs, is, clr, antialias)
-		return vs, is
-	})
+	style := &StrokeStyle{AntiAlias: antialias}
+	style.Width = strokeWidth
+	StrokePartialCircleWithStyle(dst, cx, cy, r, startAngle, totalAngle, clr, style)
 }